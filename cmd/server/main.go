@@ -2,55 +2,87 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/auth"
 	"github.com/vacano-house/vacano-ui-mcp/internal/config"
 	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+	"github.com/vacano-house/vacano-ui-mcp/internal/health"
+	"github.com/vacano-house/vacano-ui-mcp/internal/metrics"
+	"github.com/vacano-house/vacano-ui-mcp/internal/prompts"
+	"github.com/vacano-house/vacano-ui-mcp/internal/querylog"
+	"github.com/vacano-house/vacano-ui-mcp/internal/ratelimit"
+	"github.com/vacano-house/vacano-ui-mcp/internal/refresh"
 	"github.com/vacano-house/vacano-ui-mcp/internal/repo"
+	"github.com/vacano-house/vacano-ui-mcp/internal/resources"
 	"github.com/vacano-house/vacano-ui-mcp/internal/tools"
+	"github.com/vacano-house/vacano-ui-mcp/internal/webhook"
 )
 
 func main() {
+	stdio := flag.Bool("stdio", false, "serve MCP over stdin/stdout instead of HTTP")
+	flag.Parse()
+
+	// stdout carries the MCP protocol in stdio mode, so logs always go to stderr
+	log.SetOutput(os.Stderr)
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Git repo
-	repository, err := repo.New(cfg.Repo)
+	// Docs source: local directory or git repo
+	source, refreshInterval, err := newSource(cfg)
 	if err != nil {
-		log.Fatalf("Failed to init repo: %v", err)
+		log.Fatalf("Failed to init docs source: %v", err)
 	}
-	defer repository.Cleanup()
+	defer source.Cleanup()
 
-	// Clone repo
-	log.Println("Cloning repository...")
-	if err := repository.Clone(); err != nil {
-		log.Fatalf("Failed to clone repository: %v", err)
+	if err := source.Init(); err != nil {
+		log.Fatalf("Failed to init docs source: %v", err)
 	}
 
 	// Docs store
 	store := docs.NewStore()
+	thresholds := docs.Thresholds{
+		MaxRemovedEntries: cfg.Docs.MaxRemovedRatio,
+		MaxRemovedIcons:   cfg.Docs.MaxRemovedRatio,
+	}
+	refresher := refresh.New(source, store, thresholds)
+
+	// Metrics
+	m := metrics.New()
+	instrument(m, defaultVersion(cfg), refresher, store)
+
+	// Zero-result query log
+	queries, err := querylog.New(cfg.QueryLog.Size, cfg.QueryLog.File)
+	if err != nil {
+		log.Fatalf("Failed to open query log: %v", err)
+	}
+	defer queries.Close()
 
 	// Initial docs parse
-	if err := refreshDocs(repository, store); err != nil {
+	if err := refresher.Load(); err != nil {
 		log.Fatalf("Failed to parse documentation: %v", err)
 	}
 	log.Println("Documentation loaded successfully")
 
-	// Background refresh
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Versions: the default source plus any extra git refs
+	versions := docs.NewVersions(defaultVersion(cfg))
+	versions.Add(versions.DefaultName(), store)
 
-	go startRefreshLoop(ctx, cfg.Docs.RefreshInterval, repository, store)
+	extraVersions := loadExtraVersions(cfg, versions, thresholds, m)
+	for _, v := range extraVersions {
+		defer v.source.Cleanup()
+	}
 
 	// MCP server
 	server := mcp.NewServer(
@@ -60,43 +92,201 @@ func main() {
 		},
 		nil,
 	)
+	server.AddReceivingMiddleware(m.Middleware())
+
+	// Optional bearer-token auth for the HTTP endpoint
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		log.Fatalf("Failed to configure auth: %v", err)
+	}
+	if authenticator != nil {
+		server.AddReceivingMiddleware(authenticator.Middleware())
+	}
+
+	// Optional per-client rate limits on tool calls
+	limiter, err := ratelimit.New(cfg.RateLimit)
+	if err != nil {
+		log.Fatalf("Failed to configure rate limits: %v", err)
+	}
+	if limiter != nil {
+		server.AddReceivingMiddleware(limiter.Middleware(func(tool, _ string) {
+			m.RateLimited(tool)
+		}))
+	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	mcp.AddTool(server, m.Track(&mcp.Tool{
 		Name:        "search_docs",
-		Description: "Search across all vacano-ui documentation by keyword. Searches in component names, descriptions, and full content.",
-	}, tools.NewSearchHandler(store))
+		Description: "Search across all vacano-ui documentation by keyword. Searches in component names, descriptions, and full content. All words must match, falling back to docs matching any word when none match all; common words like 'how' or 'the' are ignored. Supports \"quoted phrases\", -exclude terms and OR. Results are ranked by relevance, best match first.",
+	}), tools.NewSearchHandler(versions, emptyResult(m, queries, "search_docs")))
 
-	mcp.AddTool(server, &mcp.Tool{
+	mcp.AddTool(server, m.Track(&mcp.Tool{
 		Name:        "get_component_docs",
-		Description: "Get full documentation for a specific vacano-ui component by exact name (e.g. Button, Modal, DatePicker).",
-	}, tools.NewGetComponentHandler(store))
+		Description: "Get full documentation for a specific vacano-ui component by name (e.g. Button, Modal, DatePicker). Matching ignores case and hyphens; a one-letter typo in a longer name is corrected, other unknown names return \"did you mean\" suggestions.",
+	}), tools.NewGetComponentHandler(versions, emptyResult(m, queries, "get_component_docs")))
 
-	mcp.AddTool(server, &mcp.Tool{
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "get_component_section",
+		Description: "Get a single section of a vacano-ui component's documentation by heading path (e.g. 'Props', 'Examples/Controlled') instead of the whole page. Omit the section to list the available headings.",
+	}), tools.NewGetSectionHandler(versions))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "get_component_props",
+		Description: "Get the props/API of a vacano-ui component as structured JSON: name, type, default, required and description for every prop, parsed from the component's API tables.",
+	}), tools.NewGetPropsHandler(versions))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "get_examples",
+		Description: "Get copy-pasteable code examples for a vacano-ui component, each with its language, heading and caption. Optionally filter by keyword (e.g. 'controlled', 'with icon').",
+	}), tools.NewGetExamplesHandler(versions))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
 		Name:        "list_components",
 		Description: "List all available vacano-ui components. Optionally filter by category: form, data-display, feedback, layout, navigation, utility, lib, overview, guide.",
-	}, tools.NewListHandler(store))
+	}), tools.NewListHandler(versions))
 
-	mcp.AddTool(server, &mcp.Tool{
+	mcp.AddTool(server, m.Track(&mcp.Tool{
 		Name:        "search_icons",
-		Description: "Search vacano-ui icons (1,894 Lucide icons) by name, description, or category. Icons are imported from '@vacano/ui/icons'. Use this to find the right icon for a UI element.",
-	}, tools.NewSearchIconsHandler(store))
+		Description: "Search vacano-ui icons (1,894 Lucide icons) by name, description, or category, best match first. Common synonyms are understood (delete finds Trash, settings finds Gear and Cog). Optionally restrict to one category. Icons are imported from '@vacano/ui/icons'. Use this to find the right icon for a UI element.",
+	}), tools.NewSearchIconsHandler(versions, emptyResult(m, queries, "search_icons")))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "get_icon",
+		Description: "Get a vacano-ui icon by name: its description and category, a ready-to-paste import and JSX usage snippet for '@vacano/ui/icons', and its Lucide SVG markup and path data when the server has the icon sources (a git clone has none unless ICONS_DIR is configured). Use this after search_icons to check what an icon looks like.",
+	}), tools.NewGetIconHandler(versions, emptyResult(m, queries, "get_icon")))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "list_icon_categories",
+		Description: "List the vacano-ui icon categories with the number of icons in each. Pass a category to search_icons to browse or search within it.",
+	}), tools.NewListIconCategoriesHandler(versions))
+
+	mcp.AddTool(server, m.Track(&mcp.Tool{
+		Name:        "list_versions",
+		Description: "List the vacano-ui documentation versions (git tags or branches) this server has loaded. Pass one as the 'version' argument of other tools to read docs for that release.",
+	}), tools.NewListVersionsHandler(versions))
+
+	// Version diffs need git history, so they are only offered for the git source
+	if repository, ok := source.(*repo.Repo); ok {
+		mcp.AddTool(server, m.Track(&mcp.Tool{
+			Name:        "diff_versions",
+			Description: "Compare the vacano-ui docs of two git refs (tags, branches or commits): added and removed components, changed props and descriptions per component, plus the matching CHANGELOG.md entries. Use this when upgrading @vacano/ui.",
+		}), tools.NewDiffVersionsHandler(repository))
+	}
+
+	// MCP prompts
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "build_form",
+		Title:       "Build a form with vacano-ui",
+		Description: "Build a React form with vacano-ui form components. Includes the relevant component docs.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "purpose", Description: "What the form is for (e.g. 'user signup', 'booking with date range')", Required: true},
+			{Name: "fields", Description: "Optional list of fields the form needs"},
+		},
+	}, prompts.NewBuildFormHandler(store))
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "migrate_component",
+		Title:       "Migrate this component to vacano-ui",
+		Description: "Rewrite existing React code to use vacano-ui. Includes docs for the vacano-ui counterparts of the components used.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "code", Description: "The React/JSX code to migrate", Required: true},
+			{Name: "source_library", Description: "Optional library the code uses today (e.g. MUI, Ant Design, Chakra)"},
+		},
+	}, prompts.NewMigrateComponentHandler(store))
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "pick_icon",
+		Title:       "Pick an icon for this action",
+		Description: "Choose the right vacano-ui icon for an action or UI element. Includes matching icons from the catalogue.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "action", Description: "The action or UI element needing an icon (e.g. 'delete row', 'open settings')", Required: true},
+		},
+	}, prompts.NewPickIconHandler(store))
+
+	// MCP resources
+	publisher := resources.NewPublisher(server, store)
+	publisher.Sync()
+	refresher.OnReload(publisher.Sync)
+
+	// Background refresh
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go refresher.Run(ctx, refreshInterval)
+	for _, v := range extraVersions {
+		go v.refresher.Run(ctx, cfg.Docs.RefreshInterval)
+	}
+
+	if *stdio {
+		runStdio(ctx, server)
+		return
+	}
 
 	// Streamable HTTP handler
-	handler := mcp.NewStreamableHTTPHandler(func(request *http.Request) *mcp.Server {
+	var handler http.Handler = mcp.NewStreamableHTTPHandler(func(request *http.Request) *mcp.Server {
 		return server
 	}, nil)
 
 	mux := http.NewServeMux()
-	mux.Handle("/ui", handler)
+	adminQueries := queries.Handler()
+	adminReload := refresh.NewReloadHandler(refreshers(versions.DefaultName(), refresher, extraVersions), versions.DefaultName())
+	if authenticator != nil {
+		handler = authenticator.Wrap(handler)
+		adminQueries = authenticator.Wrap(adminQueries)
+		adminReload = authenticator.Wrap(adminReload)
+		authenticator.Register(mux)
+		log.Printf("Authentication enabled: %s", authenticator.Describe())
+	}
+	if limiter != nil {
+		handler = limiter.Wrap(handler)
+	}
+	mux.Handle("/ui", http.MaxBytesHandler(handler, cfg.Server.MaxRequestBytes))
+
+	// Health, readiness and status
+	healthVersions := []health.Version{{Name: versions.DefaultName(), Refresher: refresher}}
+	for _, v := range extraVersions {
+		healthVersions = append(healthVersions, health.Version{Name: v.name, Refresher: v.refresher})
+	}
+	health.Register(mux, healthVersions, staleAfter(refreshInterval))
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/admin/queries", adminQueries)
+	mux.Handle("/admin/reload", adminReload)
+
+	// Push webhooks trigger an immediate refresh of the matching branch
+	if cfg.Webhook.Secret != "" {
+		targets := map[string]func(){cfg.Repo.Branch: refresher.Trigger}
+		for _, v := range extraVersions {
+			targets[v.name] = v.refresher.Trigger
+		}
+		mux.Handle("/webhook", webhook.NewHandler(cfg.Webhook.Secret, targets))
+		log.Println("Webhook endpoint enabled at /webhook")
+	}
 
+	runHTTP(cfg.Server, mux)
+}
+
+// runStdio serves a single MCP session over stdin/stdout until the client
+// disconnects or the process is interrupted.
+func runStdio(ctx context.Context, server *mcp.Server) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("MCP server running on stdio")
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && ctx.Err() == nil {
+		log.Printf("Stdio session ended: %v", err)
+	}
+
+	log.Println("Server stopped")
+}
+
+func runHTTP(cfg config.ServerConfig, mux *http.ServeMux) {
 	// HTTP server
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%s", cfg.Server.Port),
+		Addr:    fmt.Sprintf(":%s", cfg.Port),
 		Handler: mux,
 	}
 
 	go func() {
-		log.Printf("MCP server starting on port %s", cfg.Server.Port)
+		log.Printf("MCP server starting on port %s", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
@@ -119,54 +309,105 @@ func main() {
 	log.Println("Server stopped gracefully")
 }
 
-func refreshDocs(repository *repo.Repo, store *docs.Store) error {
-	// Fetch VitePress config for category mapping
-	configContent, err := repository.FetchVitePressConfig()
-	if err != nil {
-		log.Printf("Warning: failed to read VitePress config: %v", err)
+// newSource picks the local docs directory when DOCS_DIR is set and the git
+// repository otherwise, along with how often to poll it for changes.
+func newSource(cfg *config.Config) (repo.Source, time.Duration, error) {
+	if cfg.Docs.Dir != "" {
+		dir, err := repo.NewDir(cfg.Docs.Dir, cfg.Repo.IconsDir)
+		return dir, cfg.Docs.WatchInterval, err
 	}
-	categoryMap := docs.ParseCategories(configContent)
 
-	// Fetch and parse docs
-	files, err := repository.FetchDocs()
-	if err != nil {
-		return fmt.Errorf("failed to read docs: %w", err)
+	repository, err := repo.New(cfg.Repo)
+	return repository, cfg.Docs.RefreshInterval, err
+}
+
+// staleAfter is how long docs may go without a successful refresh before
+// /status reports them as stale: a few missed polls, and never less than a
+// minute for sources polled every second.
+func staleAfter(interval time.Duration) time.Duration {
+	return max(3*interval, time.Minute)
+}
+
+type docsVersion struct {
+	name      string
+	source    repo.Source
+	refresher *refresh.Refresher
+}
+
+// refreshers maps every loaded version to its refresher.
+func refreshers(defaultName string, def *refresh.Refresher, extra []docsVersion) map[string]*refresh.Refresher {
+	byName := map[string]*refresh.Refresher{defaultName: def}
+	for _, v := range extra {
+		byName[v.name] = v.refresher
 	}
+	return byName
+}
+
+// defaultVersion names the version served when tools get no version argument.
+func defaultVersion(cfg *config.Config) string {
+	if cfg.Docs.Dir != "" {
+		return "local"
+	}
+	return cfg.Repo.Branch
+}
 
-	entries := docs.Parse(files, categoryMap)
-	store.Reload(entries)
+// loadExtraVersions clones and parses every ref in GIT_VERSIONS into its own
+// store. Refs that fail to load are logged and skipped.
+func loadExtraVersions(cfg *config.Config, versions *docs.Versions, thresholds docs.Thresholds, m *metrics.Metrics) []docsVersion {
+	var loaded []docsVersion
 
-	// Parse icons from icons.md
-	for path, content := range files {
-		if strings.HasSuffix(path, "components/icons.md") {
-			icons := docs.ParseIcons(content)
-			store.ReloadIcons(icons)
-			log.Printf("Loaded %d icons", len(icons))
-			break
+	for _, ref := range cfg.Repo.Versions {
+		if _, exists := versions.Get(ref); exists {
+			continue
 		}
+
+		repoCfg := cfg.Repo
+		repoCfg.Branch = ref
+
+		repository, err := repo.New(repoCfg)
+		if err != nil {
+			log.Printf("Failed to init repo for version %s: %v", ref, err)
+			continue
+		}
+
+		store := docs.NewStore()
+		refresher := refresh.New(repository, store, thresholds)
+		instrument(m, ref, refresher, store)
+
+		if err := repository.Init(); err != nil {
+			log.Printf("Failed to clone version %s: %v", ref, err)
+			repository.Cleanup()
+			continue
+		}
+		if err := refresher.Load(); err != nil {
+			log.Printf("Failed to parse documentation for version %s: %v", ref, err)
+			repository.Cleanup()
+			continue
+		}
+
+		versions.Add(ref, store)
+		loaded = append(loaded, docsVersion{name: ref, source: repository, refresher: refresher})
+		log.Printf("Documentation for version %s loaded", ref)
 	}
 
-	log.Printf("Loaded %d documentation entries", len(entries))
-	return nil
+	return loaded
+}
+
+// instrument reports every refresh of a version and the resulting store size.
+func instrument(m *metrics.Metrics, version string, refresher *refresh.Refresher, store *docs.Store) {
+	refresher.OnRefresh(func(d time.Duration, err error) {
+		m.ObserveRefresh(version, d, err)
+		entries, icons := store.Counts()
+		m.SetDocsSize(version, entries, icons)
+	})
 }
 
-func startRefreshLoop(ctx context.Context, interval time.Duration, repository *repo.Repo, store *docs.Store) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			log.Println("Refreshing documentation...")
-			if err := repository.Pull(); err != nil {
-				log.Printf("Failed to pull: %v", err)
-				continue
-			}
-			if err := refreshDocs(repository, store); err != nil {
-				log.Printf("Failed to refresh docs: %v", err)
-			}
+// emptyResult counts calls of tool that found nothing and logs their query.
+func emptyResult(m *metrics.Metrics, queries *querylog.Log, tool string) tools.EmptyResultFunc {
+	return func(query string) {
+		m.EmptyResult(tool)
+		if err := queries.Record(tool, query); err != nil {
+			log.Printf("Failed to log query: %v", err)
 		}
 	}
 }
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+	"github.com/vacano-house/vacano-ui-mcp/internal/config"
+)
+
+// MetadataPath is where the OAuth protected resource metadata is served.
+const MetadataPath = "/.well-known/oauth-protected-resource"
+
+// staticTokenTTL is the expiration reported for static tokens, which never
+// expire themselves; it only has to outlast the request.
+const staticTokenTTL = time.Hour
+
+const labelKey = "label"
+
+// Authenticator checks bearer tokens on incoming requests: static tokens
+// configured by hash, and JWTs signed by a key in the local JWKS.
+type Authenticator struct {
+	tokens      Tokens
+	jwt         *JWTVerifier
+	scopes      []string
+	issuer      string
+	resourceURL string
+}
+
+// New builds an Authenticator from config. It returns nil when no tokens
+// and no JWKS are configured, leaving the server open.
+func New(cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{scopes: cfg.Scopes, issuer: cfg.Issuer, resourceURL: cfg.ResourceURL}
+
+	for _, entry := range cfg.Tokens {
+		if err := a.tokens.AddEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.TokensFile != "" {
+		if err := a.tokens.LoadFile(cfg.TokensFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.JWKSFile != "" {
+		// Tokens must be bound to this server (RFC 8707), so an audience is
+		// required; it defaults to the resource URL clients are told about
+		aud := cfg.Audience
+		if aud == "" {
+			aud = cfg.ResourceURL
+		}
+		if aud == "" {
+			return nil, errors.New("AUTH_JWKS_FILE requires AUTH_AUDIENCE or AUTH_RESOURCE_URL")
+		}
+
+		keys, err := LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwt = NewJWTVerifier(keys, cfg.Issuer, aud)
+	}
+
+	if a.tokens.Len() == 0 && a.jwt == nil {
+		return nil, nil
+	}
+
+	if a.resourceURL != "" {
+		if _, err := url.Parse(a.resourceURL); err != nil {
+			return nil, fmt.Errorf("invalid AUTH_RESOURCE_URL: %w", err)
+		}
+	}
+
+	return a, nil
+}
+
+// Describe summarizes the enabled authentication methods for logs.
+func (a *Authenticator) Describe() string {
+	desc := fmt.Sprintf("%d static token(s)", a.tokens.Len())
+	if a.jwt != nil {
+		desc += ", JWTs from JWKS"
+	}
+	return desc
+}
+
+// Verify is the TokenVerifier for static tokens and JWTs.
+func (a *Authenticator) Verify(_ context.Context, token string, r *http.Request) (*mcpauth.TokenInfo, error) {
+	if label, ok := a.tokens.Lookup(token); ok {
+		return &mcpauth.TokenInfo{
+			UserID:     label,
+			Expiration: time.Now().Add(staticTokenTTL),
+			Scopes:     a.scopes,
+			Extra:      map[string]any{labelKey: label},
+		}, nil
+	}
+
+	if a.jwt != nil {
+		claims, err := a.jwt.Verify(token, time.Now())
+		if err == nil {
+			return &mcpauth.TokenInfo{
+				UserID:     claims.Subject,
+				Expiration: claims.ExpiresAt.Time(),
+				Scopes:     claims.Scopes(),
+				Extra:      map[string]any{labelKey: claims.Client()},
+			}, nil
+		}
+		log.Printf("Rejected JWT from %s: %v", r.RemoteAddr, err)
+		return nil, fmt.Errorf("%w: %v", mcpauth.ErrInvalidToken, err)
+	}
+
+	log.Printf("Rejected unknown bearer token from %s", r.RemoteAddr)
+	return nil, mcpauth.ErrInvalidToken
+}
+
+// Wrap requires a valid bearer token on every request to h. Unauthorized
+// responses point clients at the protected resource metadata when a
+// resource URL is configured.
+func (a *Authenticator) Wrap(h http.Handler) http.Handler {
+	opts := &mcpauth.RequireBearerTokenOptions{Scopes: a.scopes}
+	if a.resourceURL != "" {
+		opts.ResourceMetadataURL = a.metadataURL()
+	}
+	return mcpauth.RequireBearerToken(a.Verify, opts)(h)
+}
+
+// Register mounts the OAuth protected resource metadata on mux when a
+// resource URL is configured. The issuer, if any, is listed as its
+// authorization server.
+func (a *Authenticator) Register(mux *http.ServeMux) {
+	if a.resourceURL == "" {
+		return
+	}
+
+	metadata := &oauthex.ProtectedResourceMetadata{
+		Resource:               a.resourceURL,
+		ScopesSupported:        a.scopes,
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           "vacano-ui docs",
+	}
+	if a.issuer != "" {
+		metadata.AuthorizationServers = []string{a.issuer}
+	}
+
+	handler := mcpauth.ProtectedResourceMetadataHandler(metadata)
+	mux.Handle(MetadataPath, handler)
+	mux.Handle(MetadataPath+"/", handler)
+}
+
+// metadataURL derives the RFC 9728 metadata URL of the resource, inserting
+// the well-known path between its origin and path.
+func (a *Authenticator) metadataURL() string {
+	u, err := url.Parse(a.resourceURL)
+	if err != nil {
+		return ""
+	}
+	u.Path = MetadataPath + u.Path
+	u.RawQuery, u.Fragment = "", ""
+	return u.String()
+}
+
+// Label returns the client label of an authenticated request, or "" when
+// the request carried no token.
+func Label(info *mcpauth.TokenInfo) string {
+	if info == nil {
+		return ""
+	}
+	if label, ok := info.Extra[labelKey].(string); ok {
+		return label
+	}
+	return info.UserID
+}
+
+// Middleware logs the client label of every new MCP session.
+func (a *Authenticator) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if params, ok := req.GetParams().(*mcp.InitializeParams); ok {
+				client := "unknown client"
+				if params != nil && params.ClientInfo != nil {
+					client = params.ClientInfo.Name
+				}
+				log.Printf("MCP session started by %s (%s)", RequestLabel(req), client)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// RequestLabel returns the client label of an MCP request, or "" when it
+// was not authenticated.
+func RequestLabel(req mcp.Request) string {
+	extra := req.GetExtra()
+	if extra == nil {
+		return ""
+	}
+	return Label(extra.TokenInfo)
+}
@@ -0,0 +1,356 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clockSkew is the leeway allowed on exp and nbf.
+const clockSkew = time.Minute
+
+// JWKS holds the public keys JWTs are verified against, by key ID.
+type JWKS struct {
+	keys map[string]crypto.PublicKey
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// LoadJWKS reads a JSON Web Key Set from a file. RSA, EC (P-256, P-384,
+// P-521) and Ed25519 keys are supported; other keys are skipped.
+func LoadJWKS(path string) (*JWKS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	jwks := &JWKS{keys: make(map[string]crypto.PublicKey)}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		pub, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: %w", key.Kid, err)
+		}
+		if pub != nil {
+			jwks.keys[key.Kid] = pub
+		}
+	}
+
+	if len(jwks.keys) == 0 {
+		return nil, errors.New("JWKS has no usable signing keys")
+	}
+
+	return jwks, nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// Claims are the JWT claims the server checks or reports.
+type Claims struct {
+	Issuer    string    `json:"iss"`
+	Subject   string    `json:"sub"`
+	Audience  audience  `json:"aud"`
+	ExpiresAt *numeric  `json:"exp"`
+	NotBefore *numeric  `json:"nbf"`
+	ClientID  string    `json:"client_id"`
+	AZP       string    `json:"azp"`
+	Scope     string    `json:"scope"`
+	SCP       scopeList `json:"scp"`
+}
+
+// Scopes returns the scopes from the scope or scp claim.
+func (c *Claims) Scopes() []string {
+	if c.Scope != "" {
+		return strings.Fields(c.Scope)
+	}
+	return c.SCP
+}
+
+// Client names the client the token was issued to, falling back to the
+// subject.
+func (c *Claims) Client() string {
+	switch {
+	case c.ClientID != "":
+		return c.ClientID
+	case c.AZP != "":
+		return c.AZP
+	}
+	return c.Subject
+}
+
+// audience accepts both the string and array forms of aud.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// scopeList accepts both the string and array forms of scp.
+type scopeList []string
+
+func (s *scopeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = strings.Fields(single)
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(s))
+}
+
+type numeric float64
+
+func (n numeric) Time() time.Time {
+	sec := int64(n)
+	return time.Unix(sec, int64((float64(n)-float64(sec))*1e9))
+}
+
+// JWTVerifier validates signed JWTs against a JWKS, their audience, and
+// optionally their issuer.
+type JWTVerifier struct {
+	keys     *JWKS
+	issuer   string
+	audience string
+}
+
+func NewJWTVerifier(keys *JWKS, issuer, audience string) *JWTVerifier {
+	return &JWTVerifier{keys: keys, issuer: issuer, audience: audience}
+}
+
+// Verify checks the signature and claims of token and returns its claims.
+func (v *JWTVerifier) Verify(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed JWT signature")
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("JWT has no expiration")
+	}
+	if now.After(claims.ExpiresAt.Time().Add(clockSkew)) {
+		return nil, errors.New("JWT expired")
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(claims.NotBefore.Time()) {
+		return nil, errors.New("JWT not valid yet")
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("unexpected JWT issuer %q", claims.Issuer)
+	}
+	if v.audience == "" || !slices.Contains(claims.Audience, v.audience) {
+		return nil, errors.New("JWT audience does not include this server")
+	}
+
+	return &claims, nil
+}
+
+func (v *JWTVerifier) key(kid string) (crypto.PublicKey, error) {
+	if key, ok := v.keys.keys[kid]; ok {
+		return key, nil
+	}
+	// Tokens without a kid are accepted when the set has a single key
+	if kid == "" && len(v.keys.keys) == 1 {
+		for _, key := range v.keys.keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown JWT key %q", kid)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	invalid := errors.New("invalid JWT signature")
+
+	switch alg {
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return invalid
+		}
+		hash := hashFor(alg)
+		digest := digest(hash, signed)
+		if strings.HasPrefix(alg, "PS") {
+			if rsa.VerifyPSS(pub, hash, digest, signature, nil) != nil {
+				return invalid
+			}
+			return nil
+		}
+		if rsa.VerifyPKCS1v15(pub, hash, digest, signature) != nil {
+			return invalid
+		}
+		return nil
+
+	case "ES256", "ES384", "ES512":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve.Params().Name != curveFor(alg) {
+			return invalid
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return invalid
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest(hashFor(alg), signed), r, s) {
+			return invalid
+		}
+		return nil
+
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(pub, signed, signature) {
+			return invalid
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported JWT algorithm %q", alg)
+}
+
+func hashFor(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	}
+	return crypto.SHA256
+}
+
+func curveFor(alg string) string {
+	switch alg {
+	case "ES384":
+		return "P-384"
+	case "ES512":
+		return "P-521"
+	}
+	return "P-256"
+}
+
+func digest(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
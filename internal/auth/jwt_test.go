@@ -0,0 +1,326 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vacano-house/vacano-ui-mcp/internal/config"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testAudience = "https://docs.example.com/ui"
+)
+
+var testNow = time.Unix(1_800_000_000, 0)
+
+type testKeys struct {
+	rsa  *rsa.PrivateKey
+	ec   *ecdsa.PrivateKey
+	path string
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := map[string]any{"keys": []map[string]string{
+		{
+			"kty": "RSA", "kid": "rsa", "use": "sig",
+			"n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{
+			"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return &testKeys{rsa: rsaKey, ec: ecKey, path: path}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func segment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64(data)
+}
+
+// sign builds a JWT with the given header and claims, signed by key with
+// the algorithm signAlg, which may differ from the alg in the header.
+func sign(t *testing.T, header, claims map[string]any, signAlg string, key any) string {
+	t.Helper()
+
+	signed := segment(t, header) + "." + segment(t, claims)
+	sum := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch signAlg {
+	case "RS256":
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, sum[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = sig
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, key.(*ecdsa.PrivateKey), sum[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case "none":
+	default:
+		t.Fatalf("unsupported test alg %s", signAlg)
+	}
+
+	return signed + "." + b64(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"iss":       testIssuer,
+		"sub":       "user-1",
+		"aud":       testAudience,
+		"exp":       testNow.Add(time.Hour).Unix(),
+		"client_id": "ide",
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	if value == nil {
+		delete(claims, key)
+	} else {
+		claims[key] = value
+	}
+	return claims
+}
+
+func TestJWTVerifierVerify(t *testing.T) {
+	keys := newTestKeys(t)
+
+	jwks, err := LoadJWKS(keys.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := NewJWTVerifier(jwks, testIssuer, testAudience)
+
+	rsaModulus := keys.rsa.N.Bytes()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{
+			name:  "valid RS256",
+			token: sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, validClaims(), "RS256", keys.rsa),
+		},
+		{
+			name:  "valid ES256",
+			token: sign(t, map[string]any{"alg": "ES256", "kid": "ec"}, validClaims(), "ES256", keys.ec),
+		},
+		{
+			name:    "alg none",
+			token:   sign(t, map[string]any{"alg": "none", "kid": "rsa"}, validClaims(), "none", nil),
+			wantErr: "unsupported JWT algorithm",
+		},
+		{
+			name:    "HS256 with the RSA public key as secret",
+			token:   sign(t, map[string]any{"alg": "HS256", "kid": "rsa"}, validClaims(), "HS256", rsaModulus),
+			wantErr: "unsupported JWT algorithm",
+		},
+		{
+			name:    "RSA key sent as ES256",
+			token:   sign(t, map[string]any{"alg": "ES256", "kid": "rsa"}, validClaims(), "RS256", keys.rsa),
+			wantErr: "invalid JWT signature",
+		},
+		{
+			name:    "EC key sent as RS256",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "ec"}, validClaims(), "ES256", keys.ec),
+			wantErr: "invalid JWT signature",
+		},
+		{
+			name:    "signature by another key",
+			token:   sign(t, map[string]any{"alg": "ES256", "kid": "ec"}, validClaims(), "ES256", mustECKey(t)),
+			wantErr: "invalid JWT signature",
+		},
+		{
+			name:    "missing kid with several keys",
+			token:   sign(t, map[string]any{"alg": "RS256"}, validClaims(), "RS256", keys.rsa),
+			wantErr: "unknown JWT key",
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "other"}, validClaims(), "RS256", keys.rsa),
+			wantErr: "unknown JWT key",
+		},
+		{
+			name:    "missing exp",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "exp", nil), "RS256", keys.rsa),
+			wantErr: "no expiration",
+		},
+		{
+			name:  "expired within clock skew",
+			token: sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "exp", testNow.Add(-30*time.Second).Unix()), "RS256", keys.rsa),
+		},
+		{
+			name:    "expired beyond clock skew",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "exp", testNow.Add(-2*time.Minute).Unix()), "RS256", keys.rsa),
+			wantErr: "expired",
+		},
+		{
+			name:  "nbf within clock skew",
+			token: sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "nbf", testNow.Add(30*time.Second).Unix()), "RS256", keys.rsa),
+		},
+		{
+			name:    "nbf beyond clock skew",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "nbf", testNow.Add(2*time.Minute).Unix()), "RS256", keys.rsa),
+			wantErr: "not valid yet",
+		},
+		{
+			name:  "aud array containing the server",
+			token: sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "aud", []string{"https://api.example.com", testAudience}), "RS256", keys.rsa),
+		},
+		{
+			name:    "aud string for another API",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "aud", "https://api.example.com"), "RS256", keys.rsa),
+			wantErr: "audience",
+		},
+		{
+			name:    "aud array without the server",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "aud", []string{"https://api.example.com"}), "RS256", keys.rsa),
+			wantErr: "audience",
+		},
+		{
+			name:    "missing aud",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "aud", nil), "RS256", keys.rsa),
+			wantErr: "audience",
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(t, map[string]any{"alg": "RS256", "kid": "rsa"}, with(validClaims(), "iss", "https://evil.example.com"), "RS256", keys.rsa),
+			wantErr: "issuer",
+		},
+		{
+			name:    "malformed",
+			token:   "not-a-jwt",
+			wantErr: "malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token, testNow)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Verify() error = %v, want nil", err)
+				}
+				if claims.Client() != "ide" {
+					t.Errorf("Client() = %q, want %q", claims.Client(), "ide")
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Verify() accepted the token, want error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Verify() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestNewRequiresAudienceForJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+
+	tests := []struct {
+		name    string
+		cfg     config.AuthConfig
+		wantAud string
+		wantErr bool
+	}{
+		{
+			name:    "no audience",
+			cfg:     config.AuthConfig{JWKSFile: keys.path},
+			wantErr: true,
+		},
+		{
+			name:    "explicit audience",
+			cfg:     config.AuthConfig{JWKSFile: keys.path, Audience: testAudience, ResourceURL: "https://other.example.com"},
+			wantAud: testAudience,
+		},
+		{
+			name:    "resource URL as audience",
+			cfg:     config.AuthConfig{JWKSFile: keys.path, ResourceURL: testAudience},
+			wantAud: testAudience,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := New(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("New() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if a.jwt.audience != tt.wantAud {
+				t.Errorf("audience = %q, want %q", a.jwt.audience, tt.wantAud)
+			}
+		})
+	}
+}
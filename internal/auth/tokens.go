@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Tokens maps SHA-256 hashes of static bearer tokens to their labels, so
+// neither the config nor the process keeps the tokens themselves.
+type Tokens struct {
+	hashes [][sha256.Size]byte
+	labels []string
+}
+
+// HashToken returns the hex SHA-256 hash of token, the form tokens are
+// configured in.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Add registers a token by its hex SHA-256 hash.
+func (t *Tokens) Add(label, hash string) error {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(hash, "sha256:"))
+	if err != nil || len(decoded) != sha256.Size {
+		return fmt.Errorf("token %q: expected a hex SHA-256 hash", label)
+	}
+
+	var sum [sha256.Size]byte
+	copy(sum[:], decoded)
+
+	t.hashes = append(t.hashes, sum)
+	t.labels = append(t.labels, label)
+	return nil
+}
+
+// AddEntry registers a "label:hash" entry.
+func (t *Tokens) AddEntry(entry string) error {
+	label, hash, ok := strings.Cut(entry, ":")
+	if !ok || label == "" {
+		return fmt.Errorf("token entry %q: expected label:sha256-hash", entry)
+	}
+	return t.Add(strings.TrimSpace(label), strings.TrimSpace(hash))
+}
+
+// LoadFile registers the "label:hash" entries of a file, one per line.
+// Blank lines and lines starting with # are skipped.
+func (t *Tokens) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open tokens file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := t.AddEntry(text); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Len returns the number of registered tokens.
+func (t *Tokens) Len() int {
+	return len(t.hashes)
+}
+
+// Lookup returns the label of token, comparing hashes in constant time.
+func (t *Tokens) Lookup(token string) (string, bool) {
+	sum := sha256.Sum256([]byte(token))
+
+	label, found := "", false
+	for i, hash := range t.hashes {
+		if subtle.ConstantTimeCompare(sum[:], hash[:]) == 1 && !found {
+			label, found = t.labels[i], true
+		}
+	}
+
+	return label, found
+}
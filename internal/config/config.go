@@ -2,29 +2,67 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server ServerConfig
-	Repo   RepoConfig
-	Docs   DocsConfig
+	Server    ServerConfig
+	Repo      RepoConfig
+	Docs      DocsConfig
+	Webhook   WebhookConfig
+	QueryLog  QueryLogConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
 }
 
 type ServerConfig struct {
-	Port string
+	Port            string
+	MaxRequestBytes int64
 }
 
 type RepoConfig struct {
-	URL    string
-	Branch string
-	SSHKey string
+	URL      string
+	Branch   string
+	SSHKey   string
+	Versions []string
+	// IconsDir holds the Lucide icon SVGs when the checkout has none
+	IconsDir string
 }
 
 type DocsConfig struct {
 	RefreshInterval time.Duration
+	Dir             string
+	WatchInterval   time.Duration
+	MaxRemovedRatio float64
+}
+
+type WebhookConfig struct {
+	Secret string
+}
+
+type QueryLogConfig struct {
+	Size int
+	File string
+}
+
+type AuthConfig struct {
+	Tokens      []string
+	TokensFile  string
+	JWKSFile    string
+	Issuer      string
+	Audience    string
+	ResourceURL string
+	Scopes      []string
+}
+
+type RateLimitConfig struct {
+	Default        string
+	Tools          []string
+	TrustedProxies []string
 }
 
 func Load() (*Config, error) {
@@ -32,21 +70,57 @@ func Load() (*Config, error) {
 
 	cfg := &Config{
 		Server: ServerConfig{
-			Port: getEnvOrDefault("APP_PORT", "3000"),
+			Port:            getEnvOrDefault("APP_PORT", "3000"),
+			MaxRequestBytes: int64(parseInt(getEnvOrDefault("MAX_REQUEST_BYTES", "1048576"))),
 		},
 		Repo: RepoConfig{
-			URL:    getEnvOrDefault("GIT_REPO_URL", "https://github.com/vacano-house/vacano-ui.git"),
-			Branch: getEnvOrDefault("GIT_BRANCH", "master"),
-			SSHKey: os.Getenv("GIT_SSH_KEY"),
+			URL:      getEnvOrDefault("GIT_REPO_URL", "https://github.com/vacano-house/vacano-ui.git"),
+			Branch:   getEnvOrDefault("GIT_BRANCH", "master"),
+			SSHKey:   os.Getenv("GIT_SSH_KEY"),
+			Versions: parseList(os.Getenv("GIT_VERSIONS")),
+			IconsDir: os.Getenv("ICONS_DIR"),
 		},
 		Docs: DocsConfig{
-			RefreshInterval: parseDuration(getEnvOrDefault("DOCS_REFRESH_INTERVAL", "5m")),
+			RefreshInterval: parseDuration(getEnvOrDefault("DOCS_REFRESH_INTERVAL", defaultRefreshInterval())),
+			Dir:             os.Getenv("DOCS_DIR"),
+			WatchInterval:   parseDuration(getEnvOrDefault("DOCS_WATCH_INTERVAL", "1s")),
+			MaxRemovedRatio: parseFloat(getEnvOrDefault("DOCS_MAX_REMOVED_RATIO", "0.2")),
+		},
+		Webhook: WebhookConfig{
+			Secret: os.Getenv("WEBHOOK_SECRET"),
+		},
+		QueryLog: QueryLogConfig{
+			Size: parseInt(getEnvOrDefault("QUERY_LOG_SIZE", "1000")),
+			File: os.Getenv("QUERY_LOG_FILE"),
+		},
+		Auth: AuthConfig{
+			Tokens:      parseList(os.Getenv("AUTH_TOKENS")),
+			TokensFile:  os.Getenv("AUTH_TOKENS_FILE"),
+			JWKSFile:    os.Getenv("AUTH_JWKS_FILE"),
+			Issuer:      os.Getenv("AUTH_ISSUER"),
+			Audience:    os.Getenv("AUTH_AUDIENCE"),
+			ResourceURL: os.Getenv("AUTH_RESOURCE_URL"),
+			Scopes:      parseList(os.Getenv("AUTH_SCOPES")),
+		},
+		RateLimit: RateLimitConfig{
+			Default:        os.Getenv("RATE_LIMIT"),
+			Tools:          parseList(os.Getenv("RATE_LIMIT_TOOLS")),
+			TrustedProxies: parseList(getEnvOrDefault("RATE_LIMIT_TRUSTED_PROXIES", "127.0.0.1/32,::1/128")),
 		},
 	}
 
 	return cfg, nil
 }
 
+// defaultRefreshInterval polls often without webhooks. With webhooks,
+// pushes trigger refreshes and polling is only a fallback for missed ones.
+func defaultRefreshInterval() string {
+	if os.Getenv("WEBHOOK_SECRET") != "" {
+		return "1h"
+	}
+	return "5m"
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -55,6 +129,32 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return value
 }
 
+func parseList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInt(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		panic("invalid integer format: " + s)
+	}
+	return n
+}
+
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic("invalid number format: " + s)
+	}
+	return f
+}
+
 func parseDuration(s string) time.Duration {
 	d, err := time.ParseDuration(s)
 	if err != nil {
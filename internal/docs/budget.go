@@ -0,0 +1,204 @@
+package docs
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is a rough average for English prose and code.
+const charsPerToken = 4
+
+// Block priorities: lower ones are dropped first when trimming.
+const (
+	priorityCode = iota
+	priorityProse
+	priorityProps
+	priorityLead
+)
+
+// EstimateTokens approximates the number of tokens text takes up.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// block is the text of one heading up to the next heading of any level.
+// The first block, usually the title and description, is the lead.
+type block struct {
+	path     string
+	level    int
+	text     string
+	tokens   int
+	priority int
+	dropped  bool
+}
+
+// FitTokens trims markdown content to about maxTokens. Whole sections are
+// dropped lowest priority first: sections with code examples (longest
+// first), then prose, then props tables; the lead block is kept. If that
+// is not enough, the rest is cut short. It returns the trimmed text and the
+// heading paths left out, in document order; prefix is prepended to them.
+func FitTokens(content string, maxTokens int, prefix string) (string, []string) {
+	if EstimateTokens(content) <= maxTokens {
+		return content, nil
+	}
+
+	blocks := splitBlocks(content, prefix)
+
+	total := 0
+	for _, b := range blocks {
+		total += b.tokens
+	}
+
+	order := make([]int, 0, len(blocks))
+	for i, b := range blocks {
+		if b.priority != priorityLead {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := blocks[order[i]], blocks[order[j]]
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		return a.tokens > b.tokens
+	})
+
+	for _, i := range order {
+		if total <= maxTokens {
+			break
+		}
+		blocks[i].dropped = true
+		total -= blocks[i].tokens
+	}
+
+	var omitted []string
+	var kept []string
+	for i, b := range blocks {
+		if !b.dropped {
+			kept = append(kept, b.text)
+			continue
+		}
+		omitted = append(omitted, b.path)
+		// Keep the heading of a dropped section whose subsections are kept
+		if hasKeptChild(blocks, i) {
+			kept = append(kept, headingLine(b.text))
+		}
+	}
+
+	text := strings.Join(kept, "\n\n")
+	if EstimateTokens(text) > maxTokens {
+		text = truncate(text, maxTokens*charsPerToken)
+		omitted = append(omitted, "(rest of the page)")
+	}
+
+	return text, omitted
+}
+
+// splitBlocks cuts content at every heading below H1.
+func splitBlocks(content, prefix string) []block {
+	lines := strings.Split(content, "\n")
+	heads := findHeadings(lines)
+
+	var blocks []block
+	var stack []heading
+
+	start, level, path := 0, 0, prefix
+	flush := func(end int) {
+		text := strings.TrimSpace(strings.Join(lines[start:end], "\n"))
+		if text == "" {
+			return
+		}
+		priority := blockPriority(text)
+		if len(blocks) == 0 {
+			priority = priorityLead
+		}
+		blocks = append(blocks, block{path: path, level: level, text: text, tokens: EstimateTokens(text), priority: priority})
+	}
+
+	for _, h := range heads {
+		if h.level == 1 {
+			continue
+		}
+		flush(h.line)
+
+		for len(stack) > 0 && stack[len(stack)-1].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, h)
+
+		titles := make([]string, 0, len(stack)+1)
+		if prefix != "" {
+			titles = append(titles, prefix)
+		}
+		for _, s := range stack {
+			titles = append(titles, s.title)
+		}
+
+		start, level, path = h.line, h.level, strings.Join(titles, "/")
+	}
+	flush(len(lines))
+
+	return blocks
+}
+
+func blockPriority(text string) int {
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.ToLower(strings.TrimSpace(line))
+		if strings.HasPrefix(trimmed, "|") && strings.Contains(trimmed, "type") {
+			return priorityProps
+		}
+	}
+	if strings.Contains(text, "```") || strings.Contains(text, "~~~") {
+		return priorityCode
+	}
+	return priorityProse
+}
+
+func hasKeptChild(blocks []block, i int) bool {
+	for _, b := range blocks[i+1:] {
+		if b.level <= blocks[i].level {
+			return false
+		}
+		if !b.dropped {
+			return true
+		}
+	}
+	return false
+}
+
+func headingLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// truncate cuts text to at most maxChars runes at a line boundary, closing
+// a code fence left open by the cut.
+func truncate(text string, maxChars int) string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:maxChars])
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i]
+	}
+
+	fence := ""
+	for _, line := range strings.Split(cut, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if marker := fenceMarker(trimmed); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		}
+	}
+	if fence != "" {
+		cut += "\n" + fence
+	}
+
+	return strings.TrimRight(cut, "\n") + "\n\n…"
+}
@@ -0,0 +1,66 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxChangelogLength caps the changelog excerpt, in runes.
+const maxChangelogLength = 8000
+
+// ChangelogBetween returns the part of a CHANGELOG.md covering releases after
+// from, up to and including to. Refs that are not version numbers (branch
+// names) match no heading: a missing "to" starts at the first release
+// heading, and a missing "from" runs until the length cap.
+func ChangelogBetween(changelog, from, to string) string {
+	if strings.TrimSpace(changelog) == "" {
+		return ""
+	}
+
+	lines := strings.Split(changelog, "\n")
+	start, end := -1, len(lines)
+	firstRelease := -1
+
+	fromRegex := versionHeadingRegex(from)
+	toRegex := versionHeadingRegex(to)
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") && !strings.HasPrefix(line, "### ") {
+			continue
+		}
+		if firstRelease == -1 && strings.HasPrefix(line, "## ") {
+			firstRelease = i
+		}
+		if start == -1 && toRegex != nil && toRegex.MatchString(line) {
+			start = i
+		}
+		if fromRegex != nil && fromRegex.MatchString(line) {
+			end = i
+			break
+		}
+	}
+
+	if start == -1 {
+		start = max(firstRelease, 0)
+	}
+	if start >= end {
+		return ""
+	}
+
+	section := strings.TrimSpace(strings.Join(lines[start:end], "\n"))
+	if cut := runeOffset(section, 0, maxChangelogLength); cut < len(section) {
+		section = section[:cut] + "\n\n…(truncated)"
+	}
+
+	return section
+}
+
+// versionHeadingRegex matches a heading mentioning ref as a version, with or
+// without a leading "v". It returns nil for refs that are not versions.
+func versionHeadingRegex(ref string) *regexp.Regexp {
+	version := strings.TrimPrefix(ref, "v")
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return nil
+	}
+	return regexp.MustCompile(`(^|[^0-9.])v?` + regexp.QuoteMeta(version) + `($|[^0-9.])`)
+}
@@ -0,0 +1,117 @@
+package docs
+
+import (
+	"sort"
+)
+
+type DocsDiff struct {
+	Added   []string          `json:"added"`
+	Removed []string          `json:"removed"`
+	Changed []ComponentChange `json:"changed"`
+}
+
+type ComponentChange struct {
+	Name              string       `json:"name"`
+	DescriptionBefore string       `json:"description_before,omitempty"`
+	DescriptionAfter  string       `json:"description_after,omitempty"`
+	AddedProps        []Prop       `json:"added_props,omitempty"`
+	RemovedProps      []Prop       `json:"removed_props,omitempty"`
+	ChangedProps      []PropChange `json:"changed_props,omitempty"`
+}
+
+type PropChange struct {
+	Name   string `json:"name"`
+	Before Prop   `json:"before"`
+	After  Prop   `json:"after"`
+}
+
+func (c *ComponentChange) DescriptionChanged() bool {
+	return c.DescriptionBefore != c.DescriptionAfter
+}
+
+// Diff compares two parsed doc sets by entry name and reports added and
+// removed entries, and per-entry description and prop changes.
+func Diff(before, after []DocEntry) DocsDiff {
+	beforeByName := make(map[string]DocEntry, len(before))
+	for _, entry := range before {
+		beforeByName[entry.Name] = entry
+	}
+	afterByName := make(map[string]DocEntry, len(after))
+	for _, entry := range after {
+		afterByName[entry.Name] = entry
+	}
+
+	var diff DocsDiff
+
+	for name, entry := range afterByName {
+		old, ok := beforeByName[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+		if change, changed := diffEntry(old, entry); changed {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+
+	for name := range beforeByName {
+		if _, ok := afterByName[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Name < diff.Changed[j].Name
+	})
+
+	return diff
+}
+
+func diffEntry(before, after DocEntry) (ComponentChange, bool) {
+	change := ComponentChange{Name: after.Name}
+	changed := false
+
+	if before.Description != after.Description {
+		change.DescriptionBefore = before.Description
+		change.DescriptionAfter = after.Description
+		changed = true
+	}
+
+	beforeProps := make(map[string]Prop, len(before.Props))
+	for _, prop := range before.Props {
+		beforeProps[propKey(prop)] = prop
+	}
+	afterProps := make(map[string]bool, len(after.Props))
+
+	for _, prop := range after.Props {
+		key := propKey(prop)
+		afterProps[key] = true
+
+		old, ok := beforeProps[key]
+		switch {
+		case !ok:
+			change.AddedProps = append(change.AddedProps, prop)
+			changed = true
+		case old != prop:
+			change.ChangedProps = append(change.ChangedProps, PropChange{Name: prop.Name, Before: old, After: prop})
+			changed = true
+		}
+	}
+
+	for _, prop := range before.Props {
+		if !afterProps[propKey(prop)] {
+			change.RemovedProps = append(change.RemovedProps, prop)
+			changed = true
+		}
+	}
+
+	return change, changed
+}
+
+// propKey identifies a prop within a page; the section keeps props of
+// subcomponents documented on the same page apart.
+func propKey(prop Prop) string {
+	return prop.Section + "\x00" + prop.Name
+}
@@ -0,0 +1,129 @@
+package docs
+
+import (
+	"strings"
+)
+
+const maxCaptionLength = 160
+
+// ParseExamples extracts fenced code blocks from markdown content, together
+// with their language, the heading path they sit under and a caption taken
+// from the paragraph right above the block.
+func ParseExamples(content string) []Example {
+	var examples []Example
+	var path []heading
+	var code []string
+	var paragraph []string
+	caption := ""
+	fence := ""
+	language := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				examples = append(examples, newExample(language, code, path, caption))
+				fence, code, caption = "", nil, ""
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			language = strings.ToLower(firstField(strings.TrimPrefix(trimmed, marker)))
+			caption = joinCaption(paragraph)
+			paragraph = nil
+			continue
+		}
+
+		if matches := headingRegex.FindStringSubmatch(line); matches != nil {
+			level := len(matches[1])
+			for len(path) > 0 && path[len(path)-1].level >= level {
+				path = path[:len(path)-1]
+			}
+			path = append(path, heading{level: level, title: matches[2]})
+			paragraph = nil
+			continue
+		}
+
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				paragraph = append(paragraph, "")
+			}
+			continue
+		}
+
+		// Only the paragraph closest to the block is kept
+		if len(paragraph) > 0 && paragraph[len(paragraph)-1] == "" {
+			paragraph = nil
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+
+	return examples
+}
+
+func newExample(language string, code []string, path []heading, caption string) Example {
+	var titles []string
+	for _, h := range path {
+		// The H1 is the page title, not a section
+		if h.level > 1 {
+			titles = append(titles, h.title)
+		}
+	}
+
+	ex := Example{
+		Language: language,
+		Code:     strings.Join(code, "\n"),
+		Path:     strings.Join(titles, "/"),
+		Caption:  caption,
+	}
+	if len(titles) > 0 {
+		ex.Heading = titles[len(titles)-1]
+	}
+
+	return ex
+}
+
+// joinCaption joins a paragraph into a caption of at most maxCaptionLength
+// runes, cut at the last space before the limit, or at a rune boundary when
+// there is none.
+func joinCaption(paragraph []string) string {
+	caption := strings.TrimSpace(strings.Join(paragraph, " "))
+	limit := runeOffset(caption, 0, maxCaptionLength)
+	if limit < len(caption) {
+		cut := strings.LastIndex(caption[:limit], " ")
+		if cut <= 0 {
+			cut = limit
+		}
+		caption = caption[:cut] + "…"
+	}
+	return caption
+}
+
+func firstField(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	// ```tsx{1,3} or ```vue:line-numbers
+	if i := strings.IndexAny(fields[0], "{:"); i >= 0 {
+		return fields[0][:i]
+	}
+	return fields[0]
+}
+
+// MatchesKeyword reports whether every word of keyword appears in the
+// example's heading path, caption or code, ignoring case.
+func (ex *Example) MatchesKeyword(keyword string) bool {
+	haystack := strings.ToLower(ex.Path + "\n" + ex.Caption + "\n" + ex.Code)
+	for _, word := range strings.Fields(strings.ToLower(keyword)) {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package docs
+
+import (
+	"sort"
+	"strings"
+)
+
+// NameMatch is a candidate entry name for a misspelled lookup.
+type NameMatch struct {
+	Name     string `json:"name"`
+	Distance int    `json:"distance"`
+}
+
+// Suggest returns up to limit entry names closest to name, by edit distance
+// between normalized names. Names containing the query (or contained in it)
+// are included even when their distance is larger.
+func (s *Store) Suggest(name string, limit int) []NameMatch {
+	snap := s.snapshot.Load()
+
+	n := normalizeName(name)
+	if n == "" {
+		return nil
+	}
+
+	maxDistance := max(2, len(n)/3)
+	var matches []NameMatch
+
+	for _, entry := range snap.Entries {
+		candidate := normalizeName(entry.Name)
+		distance := levenshtein(n, candidate)
+
+		if distance <= maxDistance || strings.Contains(candidate, n) || strings.Contains(n, candidate) {
+			matches = append(matches, NameMatch{Name: entry.Name, Distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// normalizeName lowercases a name and drops separators, so "Date Picker",
+// "date-picker" and "DatePicker" compare equal.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch r {
+		case '-', '_', ' ', '.', '\t':
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,233 @@
+package docs
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// iconSynonymGroups lists words that name the same kind of icon. Each word
+// of a query also matches icons named or described by the other words of
+// its group.
+var iconSynonymGroups = [][]string{
+	{"delete", "remove", "trash", "bin", "erase", "discard"},
+	{"settings", "gear", "cog", "preferences", "config", "options"},
+	{"close", "dismiss", "x", "cancel"},
+	{"add", "plus", "new", "create"},
+	{"edit", "pencil", "pen", "modify", "rename"},
+	{"search", "find", "magnifier", "lookup"},
+	{"user", "person", "profile", "account", "avatar"},
+	{"home", "house"},
+	{"save", "floppy", "disk"},
+	{"download", "export"},
+	{"upload", "import"},
+	{"menu", "hamburger"},
+	{"warning", "alert", "caution"},
+	{"info", "information"},
+	{"success", "check", "done", "ok", "tick", "confirm"},
+	{"mail", "email", "envelope", "inbox"},
+	{"chat", "message", "comment", "conversation"},
+	{"calendar", "date", "schedule"},
+	{"time", "clock", "timer"},
+	{"refresh", "reload", "sync", "retry"},
+	{"copy", "duplicate", "clone"},
+	{"link", "url", "chain"},
+	{"share", "send"},
+	{"notification", "bell", "alarm"},
+	{"lock", "secure", "password", "private"},
+	{"favorite", "star", "like"},
+	{"filter", "funnel"},
+	{"sort", "order"},
+	{"logout", "signout", "exit"},
+	{"login", "signin"},
+	{"expand", "maximize", "fullscreen"},
+	{"collapse", "minimize"},
+	{"show", "visible", "eye", "view"},
+	{"hide", "hidden", "invisible"},
+	{"image", "picture", "photo"},
+	{"file", "document"},
+	{"folder", "directory"},
+	{"help", "question", "support"},
+	{"more", "ellipsis", "dots", "overflow"},
+	{"location", "map", "pin", "marker"},
+	{"money", "dollar", "payment", "currency"},
+	{"cart", "basket", "shop"},
+	{"chart", "graph", "analytics", "stats"},
+	{"phone", "call"},
+}
+
+var iconSynonyms = buildIconSynonyms(iconSynonymGroups)
+
+func buildIconSynonyms(groups [][]string) map[string][]string {
+	synonyms := make(map[string][]string)
+	for _, group := range groups {
+		for _, word := range group {
+			for _, other := range group {
+				if other != word {
+					synonyms[word] = append(synonyms[word], other)
+				}
+			}
+		}
+	}
+	return synonyms
+}
+
+// Scores of the ways a query word can match an icon. Synonyms only count
+// for whole-word matches, scaled by synonymWeight.
+const (
+	iconScoreExact       = 100
+	iconScoreNamePart    = 60
+	iconScoreNamePrefix  = 40
+	iconScoreNameContain = 30
+	iconScoreDescWord    = 20
+	iconScoreDescContain = 10
+	iconScoreCategory    = 5
+	synonymWeight        = 0.75
+)
+
+type scoredIcon struct {
+	icon  IconEntry
+	score float64
+}
+
+// rankIcons returns the icons matching every word of query, best first:
+// exact names, then names made of a query word, then prefixes and
+// substrings of names, then descriptions and, if matchCategory is set,
+// categories. An empty query returns all icons in catalogue order.
+func rankIcons(icons []IconEntry, query string, matchCategory bool) []IconEntry {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return append([]IconEntry(nil), icons...)
+	}
+	whole := normalizeName(query)
+
+	var scored []scoredIcon
+	for _, icon := range icons {
+		score, ok := scoreIcon(icon, words, whole, matchCategory)
+		if ok {
+			scored = append(scored, scoredIcon{icon: icon, score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		a, b := scored[i], scored[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if len(a.icon.Name) != len(b.icon.Name) {
+			return len(a.icon.Name) < len(b.icon.Name)
+		}
+		return a.icon.Name < b.icon.Name
+	})
+
+	results := make([]IconEntry, len(scored))
+	for i, s := range scored {
+		results[i] = s.icon
+	}
+
+	return results
+}
+
+func scoreIcon(icon IconEntry, words []string, whole string, matchCategory bool) (float64, bool) {
+	name := normalizeName(icon.Name)
+	if whole != "" && name == whole {
+		return iconScoreExact * float64(max(len(words), 1)), true
+	}
+
+	parts := iconNameParts(icon.Name)
+	desc := strings.ToLower(icon.Description)
+	descWords := make(map[string]bool)
+	for _, w := range splitWords(desc) {
+		descWords[w] = true
+		descWords[stem(w)] = true
+	}
+	category := ""
+	if matchCategory {
+		category = strings.ToLower(icon.Category)
+	}
+
+	total := 0.0
+	for _, word := range words {
+		best := float64(matchIconWord(word, name, parts, desc, descWords, category, false))
+		for _, synonym := range iconSynonyms[word] {
+			s := float64(matchIconWord(synonym, name, parts, desc, descWords, category, true)) * synonymWeight
+			best = max(best, s)
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+
+	return total, true
+}
+
+// matchIconWord scores a single word against an icon. Whole-word matches
+// only is set for synonyms, whose substrings would match too loosely.
+func matchIconWord(word, name string, parts []string, desc string, descWords map[string]bool, category string, wholeOnly bool) int {
+	switch {
+	case name == word:
+		return iconScoreExact
+	case containsString(parts, word):
+		return iconScoreNamePart
+	case !wholeOnly && strings.HasPrefix(name, word):
+		return iconScoreNamePrefix
+	case !wholeOnly && strings.Contains(name, word):
+		return iconScoreNameContain
+	case descWords[word] || descWords[stem(word)]:
+		return iconScoreDescWord
+	case !wholeOnly && strings.Contains(desc, word):
+		return iconScoreDescContain
+	case !wholeOnly && category != "" && strings.Contains(category, word):
+		return iconScoreCategory
+	}
+	return 0
+}
+
+// iconNameParts splits an icon name into lowercase words without digits:
+// "Trash2" -> trash, "CircleX" -> circle, x.
+func iconNameParts(name string) []string {
+	var parts []string
+	for _, part := range splitCamel(name) {
+		part = strings.ToLower(strings.TrimFunc(part, unicode.IsDigit))
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// IconCategory is an icon category and the number of icons in it.
+type IconCategory struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// iconCategories counts icons per category, in catalogue order.
+func iconCategories(icons []IconEntry) []IconCategory {
+	index := make(map[string]int)
+	var categories []IconCategory
+
+	for _, icon := range icons {
+		i, ok := index[icon.Category]
+		if !ok {
+			i = len(categories)
+			index[icon.Category] = i
+			categories = append(categories, IconCategory{Name: icon.Category})
+		}
+		categories[i].Count++
+	}
+
+	return categories
+}
@@ -0,0 +1,36 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	svgCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	svgElementRegex = regexp.MustCompile(`<(?:path|circle|ellipse|line|polyline|polygon|rect)\b[^>]*>`)
+)
+
+// ParseIconSVGs indexes Lucide SVG files, keyed by file name (e.g. trash-2),
+// by normalized icon name so that Trash2 finds trash-2. License comments
+// are stripped.
+func ParseIconSVGs(files map[string]string) map[string]string {
+	if len(files) == 0 {
+		return nil
+	}
+
+	svgs := make(map[string]string, len(files))
+	for name, content := range files {
+		svg := strings.TrimSpace(svgCommentRegex.ReplaceAllString(content, ""))
+		if svg != "" {
+			svgs[normalizeName(name)] = svg
+		}
+	}
+
+	return svgs
+}
+
+// SVGElements returns the shape elements (paths, circles, lines, ...) of an
+// SVG in document order, i.e. what goes inside the <svg> tag.
+func SVGElements(svg string) []string {
+	return svgElementRegex.FindAllString(svg, -1)
+}
@@ -0,0 +1,309 @@
+package docs
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BM25 tuning parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type field int
+
+const (
+	fieldName field = iota
+	fieldDescription
+	fieldContent
+	numFields
+)
+
+// Name matches outweigh description matches, which outweigh body matches.
+var fieldWeights = [numFields]float64{
+	fieldName:        5.0,
+	fieldDescription: 2.0,
+	fieldContent:     1.0,
+}
+
+type indexedDoc struct {
+	length [numFields]int
+	text   [numFields]string // analyzed token stream for phrase matching
+}
+
+// posting records the term frequency of a term in one document, per field.
+type posting struct {
+	doc int
+	tf  [numFields]int
+}
+
+// searchIndex is an inverted index: every term maps to the postings of the
+// documents containing it, in document order. Queries only touch the
+// postings of their own terms.
+type searchIndex struct {
+	docs     []indexedDoc
+	postings map[string][]posting
+	avgLen   [numFields]float64
+}
+
+type scoredDoc struct {
+	doc   int
+	score float64
+}
+
+func buildIndex(entries []DocEntry) *searchIndex {
+	idx := &searchIndex{
+		docs:     make([]indexedDoc, len(entries)),
+		postings: make(map[string][]posting),
+	}
+
+	var totalLen [numFields]int
+
+	for i, entry := range entries {
+		texts := [numFields]string{
+			fieldName:        entry.Name,
+			fieldDescription: entry.Description,
+			fieldContent:     entry.Content,
+		}
+
+		tf := make(map[string]*posting)
+		for f, text := range texts {
+			terms := analyze(text)
+			for _, term := range terms {
+				p, ok := tf[term]
+				if !ok {
+					p = &posting{doc: i}
+					tf[term] = p
+				}
+				p.tf[f]++
+			}
+			idx.docs[i].length[f] = len(terms)
+			idx.docs[i].text[f] = normalizePhrase(text)
+			totalLen[f] += len(terms)
+		}
+
+		for term, p := range tf {
+			idx.postings[term] = append(idx.postings[term], *p)
+		}
+	}
+
+	if len(entries) > 0 {
+		for f := range totalLen {
+			idx.avgLen[f] = float64(totalLen[f]) / float64(len(entries))
+		}
+	}
+
+	return idx
+}
+
+// search returns every document matching the query with its BM25 score, in
+// index order; callers sort them by score. Candidates come from the postings
+// of the query's clauses, so documents sharing no term are never looked at.
+// When no document matches every word, documents matching any of them are
+// returned instead, so a question with one unmatched word still finds docs.
+func (idx *searchIndex) search(q *query) []scoredDoc {
+	if idx == nil || q.empty() {
+		return nil
+	}
+
+	results := idx.searchAll(q)
+	if len(results) == 0 && len(q.groups) > 1 {
+		results = idx.searchAll(q.anyOf())
+	}
+
+	return results
+}
+
+// searchAll returns the documents matching every group of q.
+func (idx *searchIndex) searchAll(q *query) []scoredDoc {
+	var candidates map[int]bool
+	for _, group := range q.groups {
+		matched := make(map[int]bool)
+		for _, c := range group {
+			for _, doc := range idx.clauseDocs(c) {
+				if candidates == nil || candidates[doc] {
+					matched[doc] = true
+				}
+			}
+		}
+		candidates = matched
+	}
+
+	for _, c := range q.exclude {
+		for _, doc := range idx.clauseDocs(c) {
+			delete(candidates, doc)
+		}
+	}
+
+	scores := idx.scores(q.terms())
+
+	results := make([]scoredDoc, 0, len(candidates))
+	for doc := range candidates {
+		results = append(results, scoredDoc{doc: doc, score: scores[doc]})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].doc < results[j].doc })
+
+	return results
+}
+
+// clauseDocs returns the documents matching a clause. Phrases are looked up
+// through the postings of their words, then checked against the text.
+func (idx *searchIndex) clauseDocs(c clause) []int {
+	if c.phrase == "" {
+		return postingDocs(idx.postings[c.term])
+	}
+
+	var docs []int
+	for i, word := range strings.Fields(c.phrase) {
+		found := postingDocs(idx.postings[word])
+		if i == 0 {
+			docs = found
+		} else {
+			docs = intersect(docs, found)
+		}
+	}
+
+	matched := docs[:0]
+	for _, doc := range docs {
+		for f := field(0); f < numFields; f++ {
+			if strings.Contains(idx.docs[doc].text[f], c.phrase) {
+				matched = append(matched, doc)
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func postingDocs(postings []posting) []int {
+	docs := make([]int, len(postings))
+	for i, p := range postings {
+		docs[i] = p.doc
+	}
+	return docs
+}
+
+// intersect returns the documents in both sorted lists.
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// scores sums the BM25 score of every document containing one of terms.
+func (idx *searchIndex) scores(terms []string) map[int]float64 {
+	n := float64(len(idx.docs))
+	scores := make(map[int]float64)
+
+	for _, term := range terms {
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for _, p := range postings {
+			for f := field(0); f < numFields; f++ {
+				tf := float64(p.tf[f])
+				if tf == 0 {
+					continue
+				}
+				norm := 1.0
+				if idx.avgLen[f] > 0 {
+					norm = 1 - bm25B + bm25B*float64(idx.docs[p.doc].length[f])/idx.avgLen[f]
+				}
+				scores[p.doc] += fieldWeights[f] * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+			}
+		}
+	}
+
+	return scores
+}
+
+// analyze splits text into lowercase stemmed terms. CamelCase words such as
+// "DatePicker" produce both the joined term and their parts.
+func analyze(text string) []string {
+	var terms []string
+
+	for _, word := range splitWords(text) {
+		lower := strings.ToLower(word)
+		terms = append(terms, stem(lower))
+
+		parts := splitCamel(word)
+		if len(parts) > 1 {
+			for _, part := range parts {
+				terms = append(terms, stem(strings.ToLower(part)))
+			}
+		}
+	}
+
+	return terms
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func splitCamel(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return append(parts, string(runes[start:]))
+}
+
+// stem applies a light English suffix stripping, enough to fold plurals and
+// common verb forms onto one term ("disables", "disabled" -> "disabl").
+func stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		// keep "class", "status", "axis"
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed", "ly"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return word[:len(word)-len(suffix)]
+		}
+	}
+
+	if strings.HasSuffix(word, "e") && len(word) > 4 {
+		return word[:len(word)-1]
+	}
+
+	return word
+}
@@ -15,10 +15,38 @@ const (
 )
 
 type DocEntry struct {
-	Name        string   `json:"name"`
-	Category    Category `json:"category"`
-	Description string   `json:"description"`
-	Content     string   `json:"content"`
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	Category    Category  `json:"category"`
+	Description string    `json:"description"`
+	Content     string    `json:"content"`
+	Sections    []Section `json:"sections,omitempty"`
+	Props       []Prop    `json:"props,omitempty"`
+	Examples    []Example `json:"examples,omitempty"`
+}
+
+type Section struct {
+	Title    string    `json:"title"`
+	Level    int       `json:"level"`
+	Content  string    `json:"content"`
+	Children []Section `json:"children,omitempty"`
+}
+
+type Prop struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+	Section     string `json:"section,omitempty"`
+}
+
+type Example struct {
+	Language string `json:"language"`
+	Code     string `json:"code"`
+	Heading  string `json:"heading,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Caption  string `json:"caption,omitempty"`
 }
 
 type DocEntrySummary struct {
@@ -27,6 +55,14 @@ type DocEntrySummary struct {
 	Description string   `json:"description"`
 }
 
+func (e *DocEntry) Summary() DocEntrySummary {
+	return DocEntrySummary{
+		Name:        e.Name,
+		Category:    e.Category,
+		Description: e.Description,
+	}
+}
+
 type IconEntry struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
@@ -33,7 +33,7 @@ func parseFile(path, content string, categoryMap CategoryMap) *DocEntry {
 	dir := filepath.Dir(path)
 
 	if strings.HasSuffix(dir, "guide") {
-		return parseGuide(content)
+		return parseGuide(path, content)
 	}
 
 	if strings.Contains(dir, "components") {
@@ -62,9 +62,13 @@ func parseComponent(path, content string, categoryMap CategoryMap) *DocEntry {
 
 	return &DocEntry{
 		Name:        name,
+		Path:        path,
 		Category:    category,
 		Description: extractDescription(content),
 		Content:     strings.TrimSpace(content),
+		Sections:    ParseSections(content),
+		Examples:    ParseExamples(content),
+		Props:       ParseProps(content),
 	}
 }
 
@@ -83,13 +87,17 @@ func parseLib(path, content string, categoryMap CategoryMap) *DocEntry {
 
 	return &DocEntry{
 		Name:        name,
+		Path:        path,
 		Category:    category,
 		Description: extractDescription(content),
 		Content:     strings.TrimSpace(content),
+		Sections:    ParseSections(content),
+		Examples:    ParseExamples(content),
+		Props:       ParseProps(content),
 	}
 }
 
-func parseGuide(content string) *DocEntry {
+func parseGuide(path, content string) *DocEntry {
 	name := extractH1(content)
 	if name == "" {
 		return nil
@@ -97,9 +105,12 @@ func parseGuide(content string) *DocEntry {
 
 	return &DocEntry{
 		Name:        name,
+		Path:        path,
 		Category:    CategoryGuide,
 		Description: extractDescription(content),
 		Content:     strings.TrimSpace(content),
+		Sections:    ParseSections(content),
+		Examples:    ParseExamples(content),
 	}
 }
 
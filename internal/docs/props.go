@@ -0,0 +1,175 @@
+package docs
+
+import (
+	"strings"
+)
+
+// ParseProps extracts props/API tables from markdown content. A table is
+// treated as an API table when its header has a name column (Prop, Name or
+// Property) and a Type column. Each prop records the heading the table sits
+// under, so pages documenting several subcomponents stay distinguishable.
+func ParseProps(content string) []Prop {
+	var props []Prop
+	var table []string
+	section := ""
+	fence := ""
+
+	flush := func() {
+		props = append(props, parsePropsTable(table, section)...)
+		table = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") {
+			table = append(table, trimmed)
+			continue
+		}
+		if table != nil {
+			flush()
+		}
+
+		if matches := headingRegex.FindStringSubmatch(line); matches != nil {
+			section = matches[2]
+		}
+	}
+
+	if table != nil {
+		flush()
+	}
+
+	return props
+}
+
+func parsePropsTable(rows []string, section string) []Prop {
+	if len(rows) < 3 {
+		return nil
+	}
+
+	columns := map[string]int{}
+	for i, cell := range splitTableRow(rows[0]) {
+		switch strings.ToLower(cleanCell(cell)) {
+		case "prop", "props", "name", "property", "attribute":
+			columns["name"] = i
+		case "type":
+			columns["type"] = i
+		case "default", "default value":
+			columns["default"] = i
+		case "required":
+			columns["required"] = i
+		case "description":
+			columns["description"] = i
+		}
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil
+	}
+	if _, ok := columns["type"]; !ok {
+		return nil
+	}
+
+	var props []Prop
+
+	// rows[1] is the |---|---| separator
+	for _, row := range rows[2:] {
+		cells := splitTableRow(row)
+		cell := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(cells) {
+				return ""
+			}
+			return cleanCell(cells[i])
+		}
+
+		name := cell("name")
+		required := false
+
+		if strings.HasSuffix(name, "*") {
+			name = strings.TrimSpace(strings.TrimSuffix(name, "*"))
+			required = true
+		}
+		name = strings.TrimSuffix(name, "?")
+		if name == "" {
+			continue
+		}
+
+		description := cell("description")
+		if isTruthyCell(cell("required")) || strings.Contains(description, "**Required**") || strings.HasPrefix(description, "**Required.**") {
+			required = true
+		}
+
+		def := cell("default")
+		if def == "-" || def == "—" {
+			def = ""
+		}
+
+		props = append(props, Prop{
+			Name:        name,
+			Type:        cell("type"),
+			Default:     def,
+			Required:    required,
+			Description: description,
+			Section:     section,
+		})
+	}
+
+	return props
+}
+
+// splitTableRow splits a markdown table row on unescaped pipes.
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, "\\|") {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var sb strings.Builder
+
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			sb.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(row[i])
+		}
+	}
+
+	return append(cells, sb.String())
+}
+
+// cleanCell trims whitespace and a surrounding pair of backticks.
+func cleanCell(cell string) string {
+	cell = strings.TrimSpace(cell)
+	if len(cell) >= 2 && strings.HasPrefix(cell, "`") && strings.HasSuffix(cell, "`") && strings.Count(cell, "`") == 2 {
+		cell = cell[1 : len(cell)-1]
+	}
+	return strings.TrimSpace(cell)
+}
+
+func isTruthyCell(cell string) bool {
+	switch strings.ToLower(cell) {
+	case "yes", "true", "required", "✓", "✔", "✅", "x":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package docs
+
+import (
+	"strings"
+)
+
+// query is a parsed search query. Every group must match (AND), and a group
+// matches when any of its clauses does (OR). A document matching any of the
+// excluded clauses is dropped.
+type query struct {
+	groups  [][]clause
+	exclude []clause
+}
+
+// clause is a single word or a quoted phrase.
+type clause struct {
+	term   string
+	phrase string
+}
+
+// stopwords are dropped from unquoted queries, so natural-language questions
+// ("how do I pick a date") search for their content words only.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "do": true, "does": true, "for": true,
+	"from": true, "how": true, "i": true, "if": true, "in": true, "is": true,
+	"it": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"should": true, "so": true, "that": true, "the": true, "this": true,
+	"to": true, "we": true, "what": true, "when": true, "where": true,
+	"which": true, "why": true, "with": true, "you": true, "your": true,
+}
+
+// parseQuery understands AND-by-default words, "quoted phrases", -excluded
+// words or phrases, and OR between two clauses:
+//
+//	date range -time
+//	"date picker" OR calendar
+func parseQuery(input string) *query {
+	q := &query{}
+	orPending := false
+
+	for _, tok := range tokenizeQuery(input) {
+		if tok.text == "OR" && !tok.quoted {
+			orPending = len(q.groups) > 0
+			continue
+		}
+
+		c, ok := newClause(tok)
+		if !ok {
+			orPending = false
+			continue
+		}
+
+		if tok.negated {
+			q.exclude = append(q.exclude, c)
+			orPending = false
+			continue
+		}
+
+		if orPending {
+			last := len(q.groups) - 1
+			q.groups[last] = append(q.groups[last], c)
+		} else {
+			q.groups = append(q.groups, []clause{c})
+		}
+		orPending = false
+	}
+
+	return q
+}
+
+// terms returns the analyzed terms of all positive clauses, used for scoring.
+func (q *query) terms() []string {
+	var terms []string
+	for _, group := range q.groups {
+		for _, c := range group {
+			if c.phrase != "" {
+				terms = append(terms, strings.Fields(c.phrase)...)
+			} else {
+				terms = append(terms, c.term)
+			}
+		}
+	}
+	return terms
+}
+
+// empty reports whether the query has nothing to match. Exclusions alone
+// do not make a query: "-time" would otherwise return almost every doc.
+func (q *query) empty() bool {
+	return len(q.groups) == 0
+}
+
+// anyOf returns the query with all its positive clauses in a single OR
+// group, keeping the exclusions.
+func (q *query) anyOf() *query {
+	var group []clause
+	for _, g := range q.groups {
+		group = append(group, g...)
+	}
+	return &query{groups: [][]clause{group}, exclude: q.exclude}
+}
+
+type queryToken struct {
+	text    string
+	quoted  bool
+	negated bool
+}
+
+func tokenizeQuery(input string) []queryToken {
+	var tokens []queryToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' {
+			i++
+			continue
+		}
+
+		tok := queryToken{}
+		if runes[i] == '-' && i+1 < len(runes) && runes[i+1] != ' ' {
+			tok.negated = true
+			i++
+		}
+
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tok.text = string(runes[i+1 : end])
+			tok.quoted = true
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && runes[end] != ' ' && runes[end] != '\t' && runes[end] != '\n' {
+				end++
+			}
+			tok.text = string(runes[i:end])
+			i = end
+		}
+
+		tokens = append(tokens, tok)
+	}
+
+	return tokens
+}
+
+// newClause turns a token into a clause. Unquoted tokens that contain
+// punctuation ("date-picker") are treated as phrases of their words.
+// Unquoted stopwords yield no clause.
+func newClause(tok queryToken) (clause, bool) {
+	words := splitWords(tok.text)
+	if len(words) == 0 {
+		return clause{}, false
+	}
+
+	if tok.quoted || len(words) > 1 {
+		return clause{phrase: normalizePhrase(tok.text)}, true
+	}
+
+	lower := strings.ToLower(words[0])
+	if stopwords[lower] {
+		return clause{}, false
+	}
+
+	return clause{term: stem(lower)}, true
+}
+
+// normalizePhrase turns text into the token stream phrases are matched on:
+// words split at camelCase boundaries, lowercased and stemmed, separated by
+// single spaces and padded so lookups match whole tokens only. "DatePicker"
+// and "date pickers" both become " date picker ".
+func normalizePhrase(text string) string {
+	var tokens []string
+	for _, word := range splitWords(text) {
+		for _, part := range splitCamel(word) {
+			tokens = append(tokens, stem(strings.ToLower(part)))
+		}
+	}
+	if len(tokens) == 0 {
+		return ""
+	}
+	return " " + strings.Join(tokens, " ") + " "
+}
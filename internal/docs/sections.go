@@ -0,0 +1,200 @@
+package docs
+
+import (
+	"regexp"
+	"strings"
+)
+
+var headingRegex = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+type heading struct {
+	level int
+	title string
+	line  int
+}
+
+// ParseSections splits markdown content into a tree of heading sections.
+// The H1 title is not a section itself; its subsections become the roots.
+// Headings inside fenced code blocks are ignored.
+func ParseSections(content string) []Section {
+	lines := strings.Split(content, "\n")
+	heads := findHeadings(lines)
+
+	sections := buildSections(lines, heads, len(lines))
+
+	var roots []Section
+	for _, section := range sections {
+		if section.Level == 1 {
+			roots = append(roots, section.Children...)
+			continue
+		}
+		roots = append(roots, section)
+	}
+
+	return roots
+}
+
+func findHeadings(lines []string) []heading {
+	var heads []heading
+	fence := ""
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		matches := headingRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		heads = append(heads, heading{
+			level: len(matches[1]),
+			title: matches[2],
+			line:  i,
+		})
+	}
+
+	return heads
+}
+
+func fenceMarker(line string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// buildSections turns a flat heading list into a tree. Each section runs from
+// its heading to the next heading of the same or a higher level, or to end.
+func buildSections(lines []string, heads []heading, end int) []Section {
+	var sections []Section
+
+	for i := 0; i < len(heads); {
+		h := heads[i]
+
+		j := i + 1
+		for j < len(heads) && heads[j].level > h.level {
+			j++
+		}
+
+		sectionEnd := end
+		if j < len(heads) {
+			sectionEnd = heads[j].line
+		}
+
+		sections = append(sections, Section{
+			Title:    h.title,
+			Level:    h.level,
+			Content:  strings.TrimSpace(strings.Join(lines[h.line:sectionEnd], "\n")),
+			Children: buildSections(lines, heads[i+1:j], sectionEnd),
+		})
+
+		i = j
+	}
+
+	return sections
+}
+
+// FindSection resolves a slash-separated section path such as "Props" or
+// "Examples/Controlled". Titles compare case-insensitively, ignoring spaces
+// and hyphens. A single-segment path that is not a top-level section falls
+// back to the first section with that title at any depth.
+func (e *DocEntry) FindSection(path string) *Section {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if s := normalizeName(segment); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+
+	sections := e.Sections
+	var found *Section
+
+	for _, segment := range segments {
+		found = nil
+		for i := range sections {
+			if normalizeName(sections[i].Title) == segment {
+				found = &sections[i]
+				break
+			}
+		}
+		if found == nil {
+			break
+		}
+		sections = found.Children
+	}
+
+	if found == nil && len(segments) == 1 {
+		found = findSectionDeep(e.Sections, segments[0])
+	}
+
+	return found
+}
+
+func findSectionDeep(sections []Section, title string) *Section {
+	for i := range sections {
+		if normalizeName(sections[i].Title) == title {
+			return &sections[i]
+		}
+		if found := findSectionDeep(sections[i].Children, title); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// SectionPaths lists the path of every section in document order.
+func (e *DocEntry) SectionPaths() []string {
+	var paths []string
+	collectSectionPaths(e.Sections, "", &paths)
+	return paths
+}
+
+func collectSectionPaths(sections []Section, prefix string, paths *[]string) {
+	for _, section := range sections {
+		path := section.Title
+		if prefix != "" {
+			path = prefix + "/" + section.Title
+		}
+		*paths = append(*paths, path)
+		collectSectionPaths(section.Children, path, paths)
+	}
+}
+
+// SectionPath returns the full path of a section of the entry, as accepted
+// by FindSection, or "" if it is not one of the entry's sections.
+func (e *DocEntry) SectionPath(target *Section) string {
+	return sectionPath(e.Sections, target, "")
+}
+
+func sectionPath(sections []Section, target *Section, prefix string) string {
+	for i := range sections {
+		path := sections[i].Title
+		if prefix != "" {
+			path = prefix + "/" + sections[i].Title
+		}
+		if &sections[i] == target {
+			return path
+		}
+		if found := sectionPath(sections[i].Children, target, path); found != "" {
+			return found
+		}
+	}
+	return ""
+}
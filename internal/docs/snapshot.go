@@ -0,0 +1,91 @@
+package docs
+
+import (
+	"fmt"
+)
+
+// Snapshot is an immutable, fully parsed documentation set. Entries, icons,
+// categories and the search index are built together and swapped into a
+// Store as one unit, so readers never see docs and icons from different loads.
+type Snapshot struct {
+	Entries    []DocEntry
+	Icons      []IconEntry
+	Categories CategoryMap
+	// IconSVGs holds the Lucide SVG of each icon by normalized name, when
+	// the source has them (see ParseIconSVGs).
+	IconSVGs map[string]string
+
+	index *searchIndex
+}
+
+func NewSnapshot(entries []DocEntry, icons []IconEntry, categories CategoryMap) *Snapshot {
+	return &Snapshot{
+		Entries:    entries,
+		Icons:      icons,
+		Categories: categories,
+		index:      buildIndex(entries),
+	}
+}
+
+// Thresholds are sanity limits a new snapshot must pass before it replaces
+// the current one. Ratios are the share of the current entries or icons
+// allowed to disappear in a single reload.
+type Thresholds struct {
+	MaxRemovedEntries float64
+	MaxRemovedIcons   float64
+}
+
+// Validate checks next against the current snapshot. A nil or empty current
+// snapshot only requires next to have entries.
+func (t Thresholds) Validate(current, next *Snapshot) error {
+	if len(next.Entries) == 0 {
+		return fmt.Errorf("no documentation entries parsed")
+	}
+
+	if current == nil {
+		return nil
+	}
+
+	currentNames := make([]string, len(current.Entries))
+	for i, entry := range current.Entries {
+		currentNames[i] = entry.Name
+	}
+	nextNames := make(map[string]bool, len(next.Entries))
+	for _, entry := range next.Entries {
+		nextNames[entry.Name] = true
+	}
+	if err := checkRemoved("entries", currentNames, nextNames, t.MaxRemovedEntries); err != nil {
+		return err
+	}
+
+	currentIcons := make([]string, len(current.Icons))
+	for i, icon := range current.Icons {
+		currentIcons[i] = icon.Name
+	}
+	nextIcons := make(map[string]bool, len(next.Icons))
+	for _, icon := range next.Icons {
+		nextIcons[icon.Name] = true
+	}
+
+	return checkRemoved("icons", currentIcons, nextIcons, t.MaxRemovedIcons)
+}
+
+func checkRemoved(kind string, current []string, next map[string]bool, maxRatio float64) error {
+	if len(current) == 0 {
+		return nil
+	}
+
+	removed := 0
+	for _, name := range current {
+		if !next[name] {
+			removed++
+		}
+	}
+
+	ratio := float64(removed) / float64(len(current))
+	if ratio > maxRatio {
+		return fmt.Errorf("%d of %d %s would be removed (%.0f%%, limit %.0f%%)", removed, len(current), kind, ratio*100, maxRatio*100)
+	}
+
+	return nil
+}
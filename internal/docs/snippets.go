@@ -0,0 +1,204 @@
+package docs
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// snippetWidth is the maximum length of a snippet in runes.
+const snippetWidth = 160
+
+// Snippet is a line of a doc matching a search query, with the matched
+// words in bold, and the heading path it sits under.
+type Snippet struct {
+	Heading string `json:"heading,omitempty"`
+	Text    string `json:"text"`
+}
+
+type snippetLine struct {
+	heading string
+	text    string
+	line    int
+	score   int
+}
+
+// Snippets returns up to limit lines of entry matching the positive terms
+// of query, best first: lines matching more distinct terms rank higher,
+// and each heading contributes one snippet before any gets a second. The
+// description is skipped since search results show it already.
+func Snippets(entry *DocEntry, input string, limit int) []Snippet {
+	terms := make(map[string]bool)
+	for _, term := range parseQuery(input).terms() {
+		terms[term] = true
+	}
+	if len(terms) == 0 || limit <= 0 {
+		return nil
+	}
+
+	lines := strings.Split(entry.Content, "\n")
+	headingAt := make(map[int]heading)
+	for _, h := range findHeadings(lines) {
+		headingAt[h.line] = h
+	}
+
+	var candidates []snippetLine
+	var stack []heading
+
+	for i, line := range lines {
+		if h, ok := headingAt[i]; ok {
+			if h.level == 1 {
+				continue
+			}
+			for len(stack) > 0 && stack[len(stack)-1].level >= h.level {
+				stack = stack[:len(stack)-1]
+			}
+			stack = append(stack, h)
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		if text == "" || fenceMarker(text) != "" || strings.Contains(entry.Description, text) {
+			continue
+		}
+
+		score := len(matchedTerms(text, terms))
+		if score == 0 {
+			continue
+		}
+
+		titles := make([]string, len(stack))
+		for j, h := range stack {
+			titles[j] = h.title
+		}
+		candidates = append(candidates, snippetLine{heading: strings.Join(titles, "/"), text: text, line: i, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	// One snippet per heading first, then fill up with the rest
+	var picked []snippetLine
+	used := make(map[int]bool)
+	seen := make(map[string]bool)
+	for pass := 0; pass < 2 && len(picked) < limit; pass++ {
+		for i, c := range candidates {
+			if len(picked) == limit {
+				break
+			}
+			if used[i] || (pass == 0 && seen[c.heading]) {
+				continue
+			}
+			used[i] = true
+			seen[c.heading] = true
+			picked = append(picked, c)
+		}
+	}
+
+	snippets := make([]Snippet, 0, len(picked))
+	for _, c := range picked {
+		snippets = append(snippets, Snippet{Heading: c.heading, Text: highlight(c.text, terms)})
+	}
+
+	return snippets
+}
+
+type wordSpan struct {
+	start, end int
+}
+
+// wordSpans returns the byte ranges of the words in text, split the same
+// way the index splits them.
+func wordSpans(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, wordSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{start, len(text)})
+	}
+
+	return spans
+}
+
+func wordMatches(word string, terms map[string]bool) (string, bool) {
+	for _, term := range analyze(word) {
+		if terms[term] {
+			return term, true
+		}
+	}
+	return "", false
+}
+
+func matchedTerms(text string, terms map[string]bool) map[string]bool {
+	matched := make(map[string]bool)
+	for _, span := range wordSpans(text) {
+		if term, ok := wordMatches(text[span.start:span.end], terms); ok {
+			matched[term] = true
+		}
+	}
+	return matched
+}
+
+// highlight cuts text to a window of snippetWidth runes around its first
+// match and wraps the matched words in bold.
+func highlight(text string, terms map[string]bool) string {
+	var matches []wordSpan
+	for _, span := range wordSpans(text) {
+		if _, ok := wordMatches(text[span.start:span.end], terms); ok {
+			matches = append(matches, span)
+		}
+	}
+
+	start, end := 0, len(text)
+	if utf8.RuneCountInString(text) > snippetWidth && len(matches) > 0 {
+		start = runeOffset(text, matches[0].start, -snippetWidth/3)
+		end = runeOffset(text, start, snippetWidth)
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+
+	pos := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		sb.WriteString(text[pos:m.start])
+		sb.WriteString("**" + text[m.start:m.end] + "**")
+		pos = m.end
+	}
+	sb.WriteString(text[pos:end])
+
+	if end < len(text) {
+		sb.WriteString("…")
+	}
+
+	return sb.String()
+}
+
+// runeOffset moves the byte offset from by n runes, clamped to text.
+func runeOffset(text string, from, n int) int {
+	for ; n < 0 && from > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:from])
+		from -= size
+	}
+	for ; n > 0 && from < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[from:])
+		from += size
+	}
+	return from
+}
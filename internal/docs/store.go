@@ -4,82 +4,143 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 type Store struct {
-	mu      sync.RWMutex
-	entries []DocEntry
-	icons   []IconEntry
+	snapshot atomic.Pointer[Snapshot]
+
+	// swapMu serializes check-and-swap so concurrent loads cannot race
+	swapMu sync.Mutex
 }
 
 func NewStore() *Store {
-	return &Store{}
+	s := &Store{}
+	s.snapshot.Store(NewSnapshot(nil, nil, nil))
+	return s
 }
 
-func (s *Store) Reload(entries []DocEntry) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// Swap checks next against the thresholds, comparing its entry and icon
+// names with the current snapshot, and if it passes replaces it atomically.
+// On error the current snapshot stays in place.
+func (s *Store) Swap(next *Snapshot, thresholds Thresholds) error {
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
 
-	s.entries = entries
-}
+	current := s.snapshot.Load()
+	if len(current.Entries) == 0 {
+		current = nil
+	}
 
-func (s *Store) ReloadIcons(icons []IconEntry) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := thresholds.Validate(current, next); err != nil {
+		return err
+	}
 
-	s.icons = icons
+	s.snapshot.Store(next)
+	return nil
 }
 
+// Search returns entries matching the query, ordered by relevance. Words
+// are combined with AND; quoted phrases, -excluded terms and OR are supported.
 func (s *Store) Search(query string) []DocEntry {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	snap := s.snapshot.Load()
 
-	q := strings.ToLower(query)
-	var results []DocEntry
+	scored := snap.index.search(parseQuery(query))
 
-	for _, entry := range s.entries {
-		if strings.Contains(strings.ToLower(entry.Name), q) ||
-			strings.Contains(strings.ToLower(entry.Description), q) ||
-			strings.Contains(strings.ToLower(entry.Content), q) {
-			results = append(results, entry)
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
 		}
+		return snap.Entries[scored[i].doc].Name < snap.Entries[scored[j].doc].Name
+	})
+
+	results := make([]DocEntry, 0, len(scored))
+	for _, sd := range scored {
+		results = append(results, snap.Entries[sd.doc])
 	}
 
 	return results
 }
 
+// GetByName looks up an entry by name, ignoring case, hyphens and spaces.
 func (s *Store) GetByName(name string) *DocEntry {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	snap := s.snapshot.Load()
 
 	n := strings.ToLower(name)
 
-	for _, entry := range s.entries {
+	for _, entry := range snap.Entries {
 		if strings.ToLower(entry.Name) == n {
 			return &entry
 		}
 	}
 
+	normalized := normalizeName(name)
+
+	for _, entry := range snap.Entries {
+		if normalizeName(entry.Name) == normalized {
+			return &entry
+		}
+	}
+
+	return nil
+}
+
+// Counts returns the number of loaded entries and icons.
+func (s *Store) Counts() (entries, icons int) {
+	snap := s.snapshot.Load()
+
+	return len(snap.Entries), len(snap.Icons)
+}
+
+// GetByPath looks up an entry by its source file path (e.g. docs/components/button.md).
+func (s *Store) GetByPath(path string) *DocEntry {
+	snap := s.snapshot.Load()
+
+	for _, entry := range snap.Entries {
+		if entry.Path == path {
+			return &entry
+		}
+	}
+
 	return nil
 }
 
+// Entries returns a copy of all entries sorted by name.
+func (s *Store) Entries() []DocEntry {
+	snap := s.snapshot.Load()
+
+	entries := make([]DocEntry, len(snap.Entries))
+	copy(entries, snap.Entries)
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+// Icons returns a copy of all icons in catalogue order.
+func (s *Store) Icons() []IconEntry {
+	snap := s.snapshot.Load()
+
+	icons := make([]IconEntry, len(snap.Icons))
+	copy(icons, snap.Icons)
+
+	return icons
+}
+
 func (s *Store) List(category string) []DocEntrySummary {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	snap := s.snapshot.Load()
 
 	var results []DocEntrySummary
 	cat := strings.ToLower(category)
 
-	for _, entry := range s.entries {
+	for _, entry := range snap.Entries {
 		if cat != "" && strings.ToLower(string(entry.Category)) != cat {
 			continue
 		}
 
-		results = append(results, DocEntrySummary{
-			Name:        entry.Name,
-			Category:    entry.Category,
-			Description: entry.Description,
-		})
+		results = append(results, entry.Summary())
 	}
 
 	sort.Slice(results, func(i, j int) bool {
@@ -92,20 +153,52 @@ func (s *Store) List(category string) []DocEntrySummary {
 	return results
 }
 
-func (s *Store) SearchIcons(query string) []IconEntry {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// SearchIcons ranks the icons matching query, exact names first, with
+// synonyms of the query words ("delete" finds Trash). A non-empty category
+// restricts the results to that category, ignoring case.
+func (s *Store) SearchIcons(query, category string) []IconEntry {
+	snap := s.snapshot.Load()
+
+	icons := snap.Icons
+	if category != "" {
+		cat := normalizeName(category)
+		icons = nil
+		for _, icon := range snap.Icons {
+			if normalizeName(icon.Category) == cat {
+				icons = append(icons, icon)
+			}
+		}
+	}
+
+	return rankIcons(icons, query, category == "")
+}
+
+// IconCategories returns the icon categories with their icon counts, in
+// catalogue order.
+func (s *Store) IconCategories() []IconCategory {
+	snap := s.snapshot.Load()
+	return iconCategories(snap.Icons)
+}
 
-	q := strings.ToLower(query)
-	var results []IconEntry
+// GetIcon looks up an icon by name, ignoring case and hyphens, so both
+// Trash2 and trash-2 find the same icon.
+func (s *Store) GetIcon(name string) *IconEntry {
+	snap := s.snapshot.Load()
 
-	for _, icon := range s.icons {
-		if strings.Contains(strings.ToLower(icon.Name), q) ||
-			strings.Contains(strings.ToLower(icon.Description), q) ||
-			strings.Contains(strings.ToLower(icon.Category), q) {
-			results = append(results, icon)
+	n := normalizeName(name)
+
+	for _, icon := range snap.Icons {
+		if normalizeName(icon.Name) == n {
+			return &icon
 		}
 	}
 
-	return results
+	return nil
+}
+
+// IconSVG returns the Lucide SVG of the named icon, or "" if the source
+// does not include the icon sources.
+func (s *Store) IconSVG(name string) string {
+	snap := s.snapshot.Load()
+	return snap.IconSVGs[normalizeName(name)]
 }
@@ -0,0 +1,59 @@
+package docs
+
+import (
+	"sync"
+)
+
+// Versions holds one store per documentation version (git tag or branch).
+type Versions struct {
+	mu     sync.RWMutex
+	def    string
+	names  []string
+	stores map[string]*Store
+}
+
+func NewVersions(defaultVersion string) *Versions {
+	return &Versions{
+		def:    defaultVersion,
+		stores: make(map[string]*Store),
+	}
+}
+
+// Add registers the store for a version, replacing any previous one.
+func (v *Versions) Add(name string, store *Store) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.stores[name]; !ok {
+		v.names = append(v.names, name)
+	}
+	v.stores[name] = store
+}
+
+// Get returns the store for a version; an empty name selects the default.
+func (v *Versions) Get(name string) (*Store, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if name == "" {
+		name = v.def
+	}
+
+	store, ok := v.stores[name]
+	return store, ok
+}
+
+func (v *Versions) DefaultName() string {
+	return v.def
+}
+
+// Names lists versions in the order they were added.
+func (v *Versions) Names() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	names := make([]string, len(v.names))
+	copy(names, v.names)
+
+	return names
+}
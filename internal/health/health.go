@@ -0,0 +1,71 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/vacano-house/vacano-ui-mcp/internal/refresh"
+)
+
+// Version is a named docs version whose refresh status is reported.
+type Version struct {
+	Name      string
+	Refresher *refresh.Refresher
+}
+
+type versionStatus struct {
+	Version string `json:"version"`
+	refresh.Status
+	// Stale is set when the version has had no successful refresh for
+	// maxAge, e.g. because its source is unreachable. Its cached docs are
+	// still served.
+	Stale bool `json:"stale"`
+}
+
+func newVersionStatus(v Version, maxAge time.Duration) versionStatus {
+	status := v.Refresher.Status()
+	return versionStatus{
+		Version: v.Name,
+		Status:  status,
+		Stale:   status.Ready && time.Since(status.LastSuccess) > maxAge,
+	}
+}
+
+type statusResponse struct {
+	versionStatus
+	Versions []versionStatus `json:"versions,omitempty"`
+}
+
+// Register mounts /healthz, /readyz and /status on mux. The first version is
+// the default one: readiness and the top-level status fields describe it.
+// Readiness only depends on the docs having been loaded once, since cached
+// docs keep being served when refreshes fail; versions without a successful
+// refresh for maxAge are reported as stale in /status instead.
+func Register(mux *http.ServeMux, versions []Version, maxAge time.Duration) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !versions[0].Refresher.Status().Ready {
+			http.Error(w, "docs not loaded", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		var resp statusResponse
+		resp.versionStatus = newVersionStatus(versions[0], maxAge)
+
+		if len(versions) > 1 {
+			for _, v := range versions {
+				resp.Versions = append(resp.Versions, newVersionStatus(v, maxAge))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/auth"
+)
+
+// maxClients caps the distinct values of the client label. Labels come
+// from token claims, so later clients are counted as "other" to keep the
+// number of series bounded.
+const maxClients = 100
+
+// Metrics holds the server's Prometheus metrics.
+type Metrics struct {
+	registry *Registry
+
+	// tools is filled by Track while the server is set up and only read
+	// afterwards, so it needs no lock
+	tools map[string]bool
+
+	clientsMu sync.Mutex
+	clients   map[string]bool
+
+	toolCalls       *CounterVec
+	toolDuration    *HistogramVec
+	emptyResults    *CounterVec
+	rateLimited     *CounterVec
+	refreshes       *CounterVec
+	refreshDuration *HistogramVec
+	docsEntries     *GaugeVec
+	docsIcons       *GaugeVec
+}
+
+func New() *Metrics {
+	r := NewRegistry()
+
+	return &Metrics{
+		registry: r,
+		tools:    make(map[string]bool),
+		clients:  make(map[string]bool),
+		toolCalls: r.Counter("vacano_mcp_tool_calls_total",
+			"MCP tool calls by tool, status (ok or error) and client token label.", "tool", "status", "client"),
+		toolDuration: r.Histogram("vacano_mcp_tool_duration_seconds",
+			"MCP tool call latency in seconds.", DefaultBuckets, "tool"),
+		emptyResults: r.Counter("vacano_mcp_empty_results_total",
+			"Tool calls that found nothing for the query or name.", "tool"),
+		rateLimited: r.Counter("vacano_mcp_rate_limited_total",
+			"Tool calls rejected by the rate limiter.", "tool"),
+		refreshes: r.Counter("vacano_mcp_refreshes_total",
+			"Docs refreshes by version and result (success or failure).", "version", "result"),
+		refreshDuration: r.Histogram("vacano_mcp_refresh_duration_seconds",
+			"Docs refresh duration in seconds.", []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}, "version"),
+		docsEntries: r.Gauge("vacano_mcp_docs_entries",
+			"Documentation entries in the store.", "version"),
+		docsIcons: r.Gauge("vacano_mcp_docs_icons",
+			"Icons in the store.", "version"),
+	}
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return m.registry.Handler()
+}
+
+// Track registers tool as a known tool name and returns it, so that it can
+// wrap the tool passed to mcp.AddTool. Calls of tools not tracked are
+// counted as "unknown", since tool names come from clients.
+func (m *Metrics) Track(tool *mcp.Tool) *mcp.Tool {
+	m.tools[tool.Name] = true
+	return tool
+}
+
+// Middleware counts and times tools/call requests. Calls that return a tool
+// error result or fail outright are counted with status "error", and calls
+// without a bearer token with client "anonymous".
+func (m *Metrics) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if !ok || call.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			status := "ok"
+			if res, ok := result.(*mcp.CallToolResult); err != nil || (ok && res.IsError) {
+				status = "error"
+			}
+
+			tool := m.toolLabel(call.Params.Name)
+			m.toolCalls.Inc(tool, status, m.clientLabel(auth.RequestLabel(req)))
+			m.toolDuration.Observe(time.Since(start).Seconds(), tool)
+
+			return result, err
+		}
+	}
+}
+
+// EmptyResult counts a call of tool that found nothing.
+func (m *Metrics) EmptyResult(tool string) {
+	m.emptyResults.Inc(tool)
+}
+
+// RateLimited counts a call of tool rejected by the rate limiter.
+func (m *Metrics) RateLimited(tool string) {
+	m.rateLimited.Inc(m.toolLabel(tool))
+}
+
+// ObserveRefresh records one refresh attempt of a docs version.
+func (m *Metrics) ObserveRefresh(version string, d time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	m.refreshes.Inc(version, result)
+	m.refreshDuration.Observe(d.Seconds(), version)
+}
+
+// SetDocsSize reports the number of entries and icons served for a version.
+func (m *Metrics) SetDocsSize(version string, entries, icons int) {
+	m.docsEntries.Set(float64(entries), version)
+	m.docsIcons.Set(float64(icons), version)
+}
+
+func (m *Metrics) toolLabel(name string) string {
+	if m.tools[name] {
+		return name
+	}
+	return "unknown"
+}
+
+func (m *Metrics) clientLabel(label string) string {
+	if label == "" {
+		return "anonymous"
+	}
+
+	m.clientsMu.Lock()
+	defer m.clientsMu.Unlock()
+
+	if !m.clients[label] {
+		if len(m.clients) >= maxClients {
+			return "other"
+		}
+		m.clients[label] = true
+	}
+
+	return label
+}
@@ -0,0 +1,267 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry is a minimal Prometheus registry that renders the text
+// exposition format, so /metrics needs no client library.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+type collector interface {
+	write(w io.Writer)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+// Handler serves all registered metrics.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		r.mu.Lock()
+		collectors := append([]collector(nil), r.collectors...)
+		r.mu.Unlock()
+
+		for _, c := range collectors {
+			c.write(w)
+		}
+	})
+}
+
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+// labelEscaper and helpEscaper escape what the text exposition format
+// requires, and nothing else.
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func (d *desc) header(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, helpEscaper.Replace(d.help), d.name, d.kind)
+}
+
+func (d *desc) labelString(values []string, extra ...string) string {
+	var pairs []string
+	for i, label := range d.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, labelEscaper.Replace(values[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[i], labelEscaper.Replace(extra[i+1])))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metric %s: got %d label values, want %d", d.name, len(values), len(d.labels)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// series holds label values by key, sorted on output for stable scrapes.
+type series[T any] struct {
+	mu     sync.Mutex
+	values map[string][]string
+	items  map[string]T
+}
+
+func (s *series[T]) get(key string, labels []string, create func() T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.items == nil {
+		s.items = make(map[string]T)
+		s.values = make(map[string][]string)
+	}
+
+	item, ok := s.items[key]
+	if !ok {
+		item = create()
+		s.items[key] = item
+		s.values[key] = append([]string(nil), labels...)
+	}
+
+	return item
+}
+
+func (s *series[T]) each(fn func(labels []string, item T)) {
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	s.mu.Unlock()
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s.mu.Lock()
+		item, labels := s.items[key], s.values[key]
+		s.mu.Unlock()
+		fn(labels, item)
+	}
+}
+
+// CounterVec is a counter partitioned by label values.
+type CounterVec struct {
+	desc
+	series series[*value]
+}
+
+type value struct {
+	mu sync.Mutex
+	v  float64
+}
+
+func (v *value) add(delta float64) {
+	v.mu.Lock()
+	v.v += delta
+	v.mu.Unlock()
+}
+
+func (v *value) set(x float64) {
+	v.mu.Lock()
+	v.v = x
+	v.mu.Unlock()
+}
+
+func (v *value) get() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.v
+}
+
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{desc: desc{name: name, help: help, kind: "counter", labels: labels}}
+	r.register(c)
+	return c
+}
+
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+func (c *CounterVec) Add(delta float64, labels ...string) {
+	c.series.get(c.key(labels), labels, func() *value { return &value{} }).add(delta)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.header(w)
+	c.series.each(func(labels []string, v *value) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelString(labels), formatFloat(v.get()))
+	})
+}
+
+// GaugeVec is a gauge partitioned by label values.
+type GaugeVec struct {
+	desc
+	series series[*value]
+}
+
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{desc: desc{name: name, help: help, kind: "gauge", labels: labels}}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeVec) Set(x float64, labels ...string) {
+	g.series.get(g.key(labels), labels, func() *value { return &value{} }).set(x)
+}
+
+func (g *GaugeVec) write(w io.Writer) {
+	g.header(w)
+	g.series.each(func(labels []string, v *value) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelString(labels), formatFloat(v.get()))
+	})
+}
+
+// HistogramVec is a histogram partitioned by label values.
+type HistogramVec struct {
+	desc
+	buckets []float64
+	series  series[*histogram]
+}
+
+type histogram struct {
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// DefaultBuckets suit request latencies in seconds.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{desc: desc{name: name, help: help, kind: "histogram", labels: labels}, buckets: buckets}
+	r.register(h)
+	return h
+}
+
+func (h *HistogramVec) Observe(x float64, labels ...string) {
+	hist := h.series.get(h.key(labels), labels, func() *histogram {
+		return &histogram{counts: make([]uint64, len(h.buckets))}
+	})
+
+	hist.mu.Lock()
+	defer hist.mu.Unlock()
+
+	for i, bound := range h.buckets {
+		if x <= bound {
+			hist.counts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += x
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.header(w)
+	h.series.each(func(labels []string, hist *histogram) {
+		hist.mu.Lock()
+		defer hist.mu.Unlock()
+
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(labels, "le", formatFloat(bound)), hist.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(labels, "le", "+Inf"), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelString(labels), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelString(labels), hist.count)
+	})
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,61 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+// relevantEntries returns up to limit entries matching any word of text,
+// best match first. Words shorter than three letters are ignored; stopwords
+// are dropped by Store.Search.
+func relevantEntries(store *docs.Store, text string, limit int) []docs.DocEntry {
+	var words []string
+	for _, word := range strings.FieldsFunc(text, isSeparator) {
+		if len(word) >= 3 && word != "OR" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	results := store.Search(strings.Join(words, " OR "))
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+func isSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+}
+
+// writeEntries appends the full documentation of each entry.
+func writeEntries(sb *strings.Builder, entries []docs.DocEntry) {
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("<doc name=\"%s\" category=\"%s\">\n", entry.Name, entry.Category))
+		sb.WriteString(entry.Content)
+		sb.WriteString("\n</doc>\n\n")
+	}
+}
+
+// writeSummaries appends a one-line summary of each component.
+func writeSummaries(sb *strings.Builder, summaries []docs.DocEntrySummary) {
+	for _, entry := range summaries {
+		sb.WriteString(fmt.Sprintf("- **%s** [%s] — %s\n", entry.Name, entry.Category, entry.Description))
+	}
+	sb.WriteString("\n")
+}
+
+func userPrompt(description, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: text}},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+const maxFormDocs = 4
+
+func NewBuildFormHandler(store *docs.Store) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		purpose := req.Params.Arguments["purpose"]
+		if purpose == "" {
+			return nil, fmt.Errorf("purpose argument is required")
+		}
+		fields := req.Params.Arguments["fields"]
+
+		var sb strings.Builder
+		sb.WriteString("Build a React form using the vacano-ui component library (`@vacano/ui`).\n\n")
+		sb.WriteString(fmt.Sprintf("Purpose: %s\n", purpose))
+		if fields != "" {
+			sb.WriteString(fmt.Sprintf("Fields: %s\n", fields))
+		}
+		sb.WriteString("\nUse only vacano-ui components and the props documented below. ")
+		sb.WriteString("Wire up controlled state and validation, and include a submit button.\n\n")
+
+		sb.WriteString("## Available form components\n\n")
+		writeSummaries(&sb, store.List(string(docs.CategoryForm)))
+
+		if entries := relevantEntries(store, purpose+" "+fields, maxFormDocs); len(entries) > 0 {
+			sb.WriteString("## Relevant documentation\n\n")
+			writeEntries(&sb, entries)
+		}
+
+		return userPrompt("Build a form with vacano-ui", sb.String()), nil
+	}
+}
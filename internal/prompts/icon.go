@@ -0,0 +1,70 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+const maxIconCandidates = 40
+
+func NewPickIconHandler(store *docs.Store) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		action := req.Params.Arguments["action"]
+		if action == "" {
+			return nil, fmt.Errorf("action argument is required")
+		}
+
+		var sb strings.Builder
+		sb.WriteString("Pick the best vacano-ui icon for this action or UI element:\n\n")
+		sb.WriteString(fmt.Sprintf("> %s\n\n", action))
+		sb.WriteString("Icons are imported from `@vacano/ui/icons` (Lucide icon set). ")
+		sb.WriteString("Recommend one icon, name one or two alternatives, and show the import and JSX usage.\n\n")
+
+		candidates := iconCandidates(store, action)
+		if len(candidates) == 0 {
+			sb.WriteString("No icon matched the words of the action directly; choose from the catalogue categories:\n\n")
+			writeIconCategories(&sb, store.IconCategories())
+		} else {
+			sb.WriteString("## Candidate icons\n\n")
+			for _, icon := range candidates {
+				sb.WriteString(fmt.Sprintf("- `%s` [%s] — %s\n", icon.Name, icon.Category, icon.Description))
+			}
+		}
+
+		return userPrompt("Pick an icon for an action", sb.String()), nil
+	}
+}
+
+// iconCandidates collects icons matching any word of the action.
+func iconCandidates(store *docs.Store, action string) []docs.IconEntry {
+	seen := make(map[string]bool)
+	var candidates []docs.IconEntry
+
+	for _, word := range strings.FieldsFunc(action, isSeparator) {
+		if len(word) < 3 {
+			continue
+		}
+		for _, icon := range store.SearchIcons(word, "") {
+			if seen[icon.Name] {
+				continue
+			}
+			seen[icon.Name] = true
+			candidates = append(candidates, icon)
+			if len(candidates) == maxIconCandidates {
+				return candidates
+			}
+		}
+	}
+
+	return candidates
+}
+
+func writeIconCategories(sb *strings.Builder, categories []docs.IconCategory) {
+	for _, category := range categories {
+		sb.WriteString(fmt.Sprintf("- %s (%d icons)\n", category.Name, category.Count))
+	}
+}
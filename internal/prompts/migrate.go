@@ -0,0 +1,85 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+const maxMigrateDocs = 6
+
+var jsxTagRegex = regexp.MustCompile(`<([A-Z][A-Za-z0-9]*)`)
+
+var camelBoundaryRegex = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+func NewMigrateComponentHandler(store *docs.Store) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		code := req.Params.Arguments["code"]
+		if code == "" {
+			return nil, fmt.Errorf("code argument is required")
+		}
+		source := req.Params.Arguments["source_library"]
+
+		var sb strings.Builder
+		sb.WriteString("Migrate the following React code to the vacano-ui component library (`@vacano/ui`).")
+		if source != "" {
+			sb.WriteString(fmt.Sprintf(" The code currently uses %s.", source))
+		}
+		sb.WriteString("\n\nReplace each UI element with its closest vacano-ui equivalent, map props using the documentation below, ")
+		sb.WriteString("and call out anything that has no direct equivalent.\n\n")
+		sb.WriteString("```tsx\n")
+		sb.WriteString(strings.TrimSpace(code))
+		sb.WriteString("\n```\n\n")
+
+		sb.WriteString("## All vacano-ui components\n\n")
+		writeSummaries(&sb, store.List(""))
+
+		if entries := componentsInCode(store, code, maxMigrateDocs); len(entries) > 0 {
+			sb.WriteString("## Documentation for matching components\n\n")
+			writeEntries(&sb, entries)
+		}
+
+		return userPrompt("Migrate a component to vacano-ui", sb.String()), nil
+	}
+}
+
+// componentsInCode resolves JSX tag names used in code to vacano-ui entries,
+// falling back to a relevance search over the tag names.
+func componentsInCode(store *docs.Store, code string, limit int) []docs.DocEntry {
+	seen := make(map[string]bool)
+	var entries []docs.DocEntry
+	var unmatched []string
+
+	for _, match := range jsxTagRegex.FindAllStringSubmatch(code, -1) {
+		tag := match[1]
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+
+		if entry := store.GetByName(tag); entry != nil && !seen[entry.Name] {
+			seen[entry.Name] = true
+			entries = append(entries, *entry)
+			continue
+		}
+		// "MuiTextField" searches as "Mui Text Field"
+		unmatched = append(unmatched, camelBoundaryRegex.ReplaceAllString(tag, "$1 $2"))
+	}
+
+	for _, entry := range relevantEntries(store, strings.Join(unmatched, " "), limit) {
+		if !seen[entry.Name] {
+			seen[entry.Name] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
@@ -0,0 +1,329 @@
+package querylog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxQueryBytes caps the length of a logged query. Requests may be large,
+// but nobody reads a query that long; it only bloats the file.
+const maxQueryBytes = 1024
+
+// Entry is one tool call that found nothing.
+type Entry struct {
+	Query string    `json:"query"`
+	Tool  string    `json:"tool"`
+	Time  time.Time `json:"time"`
+}
+
+// QueryCount aggregates the entries of one normalized query.
+type QueryCount struct {
+	Query    string    `json:"query"`
+	Count    int       `json:"count"`
+	Tools    []string  `json:"tools"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Log keeps the most recent zero-result queries in a ring of fixed size,
+// optionally mirrored to a JSONL file so they survive restarts.
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+
+	path  string
+	file  *os.File
+	lines int
+}
+
+// New returns a log holding up to size entries. When path is set, entries
+// already in the file are loaded and new ones are appended to it; the file
+// is compacted back to the ring once it grows past twice its size.
+func New(size int, path string) (*Log, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("query log size must be positive, got %d", size)
+	}
+
+	l := &Log{entries: make([]Entry, size), path: path}
+	if path == "" {
+		return l, nil
+	}
+
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	if err := l.compact(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *Log) load() error {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open query log: %w", err)
+	}
+	defer f.Close()
+
+	// A reader rather than a scanner, so a line of any length (e.g. written
+	// before queries were truncated) cannot stop the server from starting
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var entry Entry
+			if json.Unmarshal(line, &entry) == nil {
+				entry.Query = truncate(entry.Query)
+				l.add(entry)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read query log: %w", err)
+		}
+	}
+}
+
+// compact rewrites the file with the entries currently in the ring. The
+// current file is only replaced once the new one is complete.
+func (l *Log) compact() error {
+	tmp := l.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to write query log: %w", err)
+	}
+
+	enc := json.NewEncoder(f)
+	recent := l.recent()
+	for _, entry := range recent {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return fmt.Errorf("failed to write query log: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write query log: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write query log: %w", err)
+	}
+
+	l.lines = len(recent)
+	return l.reopen()
+}
+
+// reopen (re)opens the file for appending.
+func (l *Log) reopen() error {
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open query log: %w", err)
+	}
+	l.file = f
+
+	return nil
+}
+
+// Record adds a zero-result call of tool for query.
+func (l *Log) Record(tool, query string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := Entry{Query: truncate(query), Tool: tool, Time: time.Now().UTC()}
+	l.add(entry)
+
+	if l.path == "" {
+		return nil
+	}
+
+	if l.lines >= 2*len(l.entries) {
+		err := l.compact()
+		if err == nil {
+			return nil
+		}
+		// Keep appending to the uncompacted file; compaction is retried on
+		// the next record
+		log.Printf("Failed to compact query log: %v", err)
+	}
+
+	if l.file == nil {
+		if err := l.reopen(); err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append to query log: %w", err)
+	}
+	l.lines++
+
+	return nil
+}
+
+func (l *Log) add(entry Entry) {
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns the logged entries, oldest first.
+func (l *Log) Recent() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.recent()
+}
+
+func (l *Log) recent() []Entry {
+	if !l.full {
+		return append([]Entry(nil), l.entries[:l.next]...)
+	}
+
+	recent := make([]Entry, 0, len(l.entries))
+	recent = append(recent, l.entries[l.next:]...)
+	return append(recent, l.entries[:l.next]...)
+}
+
+// Aggregate counts the logged entries by normalized query, most frequent
+// first. An empty tool includes every tool.
+func (l *Log) Aggregate(tool string) []QueryCount {
+	byQuery := make(map[string]*QueryCount)
+
+	for _, entry := range l.Recent() {
+		if tool != "" && entry.Tool != tool {
+			continue
+		}
+
+		key := Normalize(entry.Query)
+		count, ok := byQuery[key]
+		if !ok {
+			count = &QueryCount{Query: key}
+			byQuery[key] = count
+		}
+
+		count.Count++
+		if entry.Time.After(count.LastSeen) {
+			count.LastSeen = entry.Time
+		}
+		if !contains(count.Tools, entry.Tool) {
+			count.Tools = append(count.Tools, entry.Tool)
+		}
+	}
+
+	counts := make([]QueryCount, 0, len(byQuery))
+	for _, count := range byQuery {
+		sort.Strings(count.Tools)
+		counts = append(counts, *count)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Query < counts[j].Query
+	})
+
+	return counts
+}
+
+// Close closes the backing file, if any.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Normalize lowercases a query and collapses its whitespace, so "Date
+// Picker" and "date  picker" count as the same query.
+func Normalize(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+type report struct {
+	Total   int          `json:"total"`
+	Queries []QueryCount `json:"queries"`
+	Recent  []Entry      `json:"recent,omitempty"`
+}
+
+// Handler serves the aggregated log as JSON. ?tool= restricts it to one
+// tool and ?recent=1 also lists the raw entries, newest first.
+func (l *Log) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tool := r.URL.Query().Get("tool")
+
+		resp := report{Queries: l.Aggregate(tool)}
+		for _, count := range resp.Queries {
+			resp.Total += count.Count
+		}
+
+		if r.URL.Query().Get("recent") != "" {
+			recent := l.Recent()
+			for i := len(recent) - 1; i >= 0; i-- {
+				if tool == "" || recent[i].Tool == tool {
+					resp.Recent = append(resp.Recent, recent[i])
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// truncate cuts query to maxQueryBytes on a rune boundary.
+func truncate(query string) string {
+	if len(query) <= maxQueryBytes {
+		return query
+	}
+
+	cut := maxQueryBytes
+	for cut > 0 && !utf8.RuneStart(query[cut]) {
+		cut--
+	}
+	return query[:cut]
+}
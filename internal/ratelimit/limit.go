@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: Burst calls at once, refilled at Rate calls per
+// second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses "N/s", "N/m" or "N/h": N calls per second, minute or
+// hour, with bursts of up to N.
+func ParseLimit(s string) (Limit, error) {
+	count, unit, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected N/s, N/m or N/h", s)
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: count must be a positive integer", s)
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid rate limit %q: unit must be s, m or h", s)
+	}
+
+	return Limit{Rate: float64(n) / per.Seconds(), Burst: n}, nil
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket for the time since its last use and takes one
+// token. When empty, it returns how long until a token is available.
+func (b *bucket) take(limit Limit, now time.Time) (bool, time.Duration) {
+	b.tokens = min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / limit.Rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// full reports whether the bucket has refilled completely by now, so
+// forgetting it changes nothing.
+func (b *bucket) full(limit Limit, now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst)
+}
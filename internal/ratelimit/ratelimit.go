@@ -0,0 +1,218 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/auth"
+	"github.com/vacano-house/vacano-ui-mcp/internal/config"
+)
+
+// ClientIPHeader carries the client address resolved by Wrap to the MCP
+// middleware. Wrap always overwrites it, so clients cannot set it.
+const ClientIPHeader = "X-Vacano-Client-Ip"
+
+// CodeRateLimited is the JSON-RPC error code of throttled tool calls.
+const CodeRateLimited = -32029
+
+// sweepInterval is how often buckets that have refilled are dropped.
+const sweepInterval = time.Minute
+
+// Limiter throttles tool calls per client with a token bucket per client
+// and tool. Clients are identified by their token label when authenticated
+// and by IP address otherwise.
+type Limiter struct {
+	def     *Limit
+	tools   map[string]Limit
+	proxies []*net.IPNet
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New builds a Limiter from config. It returns nil when no limits are
+// configured.
+func New(cfg config.RateLimitConfig) (*Limiter, error) {
+	l := &Limiter{
+		tools:   make(map[string]Limit),
+		buckets: make(map[string]*bucket),
+	}
+
+	if cfg.Default != "" {
+		def, err := ParseLimit(cfg.Default)
+		if err != nil {
+			return nil, err
+		}
+		l.def = &def
+	}
+
+	for _, entry := range cfg.Tools {
+		tool, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tool rate limit %q: expected tool=N/unit", entry)
+		}
+		limit, err := ParseLimit(spec)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tool, err)
+		}
+		l.tools[strings.TrimSpace(tool)] = limit
+	}
+
+	if l.def == nil && len(l.tools) == 0 {
+		return nil, nil
+	}
+
+	for _, cidr := range cfg.TrustedProxies {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		l.proxies = append(l.proxies, network)
+	}
+
+	return l, nil
+}
+
+func (l *Limiter) limit(tool string) (Limit, bool) {
+	if limit, ok := l.tools[tool]; ok {
+		return limit, true
+	}
+	if l.def != nil {
+		return *l.def, true
+	}
+	return Limit{}, false
+}
+
+// Allow takes a token for a call of tool by client. When the bucket is
+// empty it returns false and how long until the next call is allowed.
+func (l *Limiter) Allow(client, tool string, now time.Time) (bool, time.Duration) {
+	limit, ok := l.limit(tool)
+	if !ok {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	key := client + "\x00" + tool
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	return b.take(limit, now)
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		_, tool, _ := strings.Cut(key, "\x00")
+		if limit, ok := l.limit(tool); !ok || b.full(limit, now) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Wrap resolves the client IP of every request to h for the middleware.
+func (l *Limiter) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(ClientIPHeader, l.clientIP(r))
+		h.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the peer address, or when the peer is a trusted proxy,
+// the nearest untrusted address in X-Forwarded-For.
+func (l *Limiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !l.trusted(host) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !l.trusted(hop) {
+			return hop
+		}
+		host = hop
+	}
+
+	return host
+}
+
+func (l *Limiter) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range l.proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware rejects tools/call requests over the limit with a JSON-RPC
+// error carrying the seconds to wait in retry_after. onThrottle, if set,
+// is called with the tool and client of every rejected call. Requests that
+// did not come over HTTP are not limited.
+func (l *Limiter) Middleware(onThrottle func(tool, client string)) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if !ok || call.Params == nil || req.GetExtra() == nil {
+				return next(ctx, method, req)
+			}
+
+			client := clientKey(req)
+			allowed, wait := l.Allow(client, call.Params.Name, time.Now())
+			if allowed {
+				return next(ctx, method, req)
+			}
+
+			if onThrottle != nil {
+				onThrottle(call.Params.Name, client)
+			}
+
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			data, _ := json.Marshal(map[string]any{"retry_after": retryAfter})
+			return nil, &jsonrpc.Error{
+				Code:    CodeRateLimited,
+				Message: fmt.Sprintf("rate limit exceeded for %s, retry in %ds", call.Params.Name, retryAfter),
+				Data:    data,
+			}
+		}
+	}
+}
+
+// clientKey identifies the caller by token label, falling back to the IP
+// address resolved by Wrap.
+func clientKey(req mcp.Request) string {
+	if label := auth.RequestLabel(req); label != "" {
+		return "token:" + label
+	}
+	return "ip:" + req.GetExtra().Header.Get(ClientIPHeader)
+}
@@ -0,0 +1,48 @@
+package refresh
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// NewReloadHandler serves POST /admin/reload. It triggers a refresh of the
+// version named by the version query parameter, or of defaultVersion. With
+// force=1 the docs are reloaded even if unchanged and the removal thresholds
+// are skipped, to accept a deliberate removal of many docs.
+func NewReloadHandler(refreshers map[string]*Refresher, defaultVersion string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		version := r.URL.Query().Get("version")
+		if version == "" {
+			version = defaultVersion
+		}
+
+		refresher, ok := refreshers[version]
+		if !ok {
+			names := make([]string, 0, len(refreshers))
+			for name := range refreshers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			http.Error(w, fmt.Sprintf("unknown version %q, available: %s", version, strings.Join(names, ", ")), http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("force") == "1" {
+			log.Printf("Forced reload of version %s requested by %s", version, r.RemoteAddr)
+			refresher.Force()
+		} else {
+			refresher.Trigger()
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "reload of %s triggered\n", version)
+	})
+}
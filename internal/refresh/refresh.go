@@ -0,0 +1,300 @@
+package refresh
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+	"github.com/vacano-house/vacano-ui-mcp/internal/repo"
+)
+
+// retryInterval is the minimum time between retries of a load that failed.
+const retryInterval = time.Minute
+
+// Refresher loads documentation from a source into a store and keeps it up
+// to date.
+type Refresher struct {
+	source     repo.Source
+	store      *docs.Store
+	thresholds docs.Thresholds
+	onReload   []func()
+	onRefresh  []func(time.Duration, error)
+	trigger    chan struct{}
+	force      atomic.Bool
+
+	mu     sync.Mutex
+	status Status
+	// stale is set while the source has docs that failed to load, so that
+	// sources without revisions are retried too
+	stale bool
+	// lastLoad is when docs were last loaded, successfully or not, to space
+	// out retries
+	lastLoad time.Time
+}
+
+// Status describes the outcome of the most recent refreshes. LastRefresh is
+// the last poll or load, whatever its outcome. LastSuccess is the last time
+// the served docs were known to be current: a successful load, or a poll
+// that found no changes while no load is pending.
+type Status struct {
+	Ready       bool      `json:"ready"`
+	Commit      string    `json:"commit,omitempty"`
+	LastRefresh time.Time `json:"last_refresh"`
+	LastSuccess time.Time `json:"last_success"`
+	LastError   string    `json:"last_error,omitempty"`
+	Entries     int       `json:"entries"`
+	Icons       int       `json:"icons"`
+}
+
+// revisioner is implemented by sources that know their current commit.
+type revisioner interface {
+	Head() (string, error)
+}
+
+func New(source repo.Source, store *docs.Store, thresholds docs.Thresholds) *Refresher {
+	return &Refresher{
+		source:     source,
+		store:      store,
+		thresholds: thresholds,
+		trigger:    make(chan struct{}, 1),
+	}
+}
+
+// Trigger asks Run to check the source right away instead of waiting for
+// the next tick. Triggers arriving while one is pending are coalesced.
+func (r *Refresher) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Force asks Run to reload the docs right away even if the source has not
+// changed, skipping the removal thresholds. It lets operators accept a
+// deliberate large removal of docs that the thresholds keep rejecting.
+func (r *Refresher) Force() {
+	r.force.Store(true)
+	r.Trigger()
+}
+
+// OnReload registers fn to be called after every successful reload.
+func (r *Refresher) OnReload(fn func()) {
+	r.onReload = append(r.onReload, fn)
+}
+
+// OnRefresh registers fn to be called after every refresh attempt with its
+// duration and error, nil on success.
+func (r *Refresher) OnRefresh(fn func(time.Duration, error)) {
+	r.onRefresh = append(r.onRefresh, fn)
+}
+
+// Load parses the source into a new snapshot and swaps it into the store.
+// If parsing fails or the snapshot does not pass the sanity thresholds,
+// the store keeps its current snapshot.
+func (r *Refresher) Load() error {
+	return r.loadWith(r.thresholds)
+}
+
+func (r *Refresher) loadWith(thresholds docs.Thresholds) error {
+	start := time.Now()
+	err := r.load(thresholds)
+
+	r.mu.Lock()
+	r.stale = err != nil
+	r.lastLoad = time.Now()
+	r.mu.Unlock()
+
+	r.finish(start, err)
+	return err
+}
+
+func (r *Refresher) load(thresholds docs.Thresholds) error {
+	// Fetch VitePress config for category mapping
+	configContent, err := r.source.FetchVitePressConfig()
+	if err != nil {
+		log.Printf("Warning: failed to read VitePress config: %v", err)
+	}
+	categoryMap := docs.ParseCategories(configContent)
+
+	// Fetch and parse docs
+	files, err := r.source.FetchDocs()
+	if err != nil {
+		return fmt.Errorf("failed to read docs: %w", err)
+	}
+
+	entries := docs.Parse(files, categoryMap)
+
+	// Parse icons from icons.md
+	var icons []docs.IconEntry
+	for path, content := range files {
+		if strings.HasSuffix(path, "components/icons.md") {
+			icons = docs.ParseIcons(content)
+			break
+		}
+	}
+
+	// Icon SVGs are optional: they are only there when the source includes
+	// the Lucide sources
+	svgs, err := r.source.FetchIconSVGs()
+	if err != nil {
+		log.Printf("Warning: failed to read icon SVGs: %v", err)
+	}
+
+	snapshot := docs.NewSnapshot(entries, icons, categoryMap)
+	snapshot.IconSVGs = docs.ParseIconSVGs(svgs)
+	if err := r.store.Swap(snapshot, thresholds); err != nil {
+		return fmt.Errorf("rejected docs snapshot, keeping the previous one: %w", err)
+	}
+
+	log.Printf("Loaded %d documentation entries, %d icons and %d icon SVGs", len(entries), len(icons), len(snapshot.IconSVGs))
+
+	for _, fn := range r.onReload {
+		fn()
+	}
+
+	return nil
+}
+
+// Run polls the source every interval, or when triggered, and reloads the
+// docs when they change.
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.update()
+		case <-r.trigger:
+			r.update()
+		}
+	}
+}
+
+func (r *Refresher) update() {
+	force := r.force.Swap(false)
+
+	start := time.Now()
+	changed, err := r.source.Update()
+	if err != nil {
+		log.Printf("Failed to update docs source: %v", err)
+		r.finish(start, err)
+		return
+	}
+
+	thresholds := r.thresholds
+	switch {
+	case force:
+		log.Println("Forced documentation reload, skipping removal thresholds...")
+		thresholds = docs.Thresholds{MaxRemovedEntries: 1, MaxRemovedIcons: 1}
+	case changed:
+		log.Println("Refreshing documentation...")
+	case r.behind():
+		log.Println("Retrying documentation load of a revision that failed to load...")
+	default:
+		r.recordPoll()
+		return
+	}
+
+	if err := r.loadWith(thresholds); err != nil {
+		log.Printf("Failed to refresh docs: %v", err)
+	}
+}
+
+// behind reports whether the source has docs that are not loaded, e.g.
+// because their load was rejected: its HEAD differs from the last loaded
+// commit, or, for sources without revisions, the last load failed. Retries
+// are spaced by retryInterval, as sources may be polled every second.
+func (r *Refresher) behind() bool {
+	r.mu.Lock()
+	loaded, stale, last := r.status.Commit, r.stale, r.lastLoad
+	r.mu.Unlock()
+
+	if time.Since(last) < retryInterval {
+		return false
+	}
+
+	if rev, ok := r.source.(revisioner); ok {
+		head, err := rev.Head()
+		if err != nil {
+			return false
+		}
+		if head == loaded {
+			// The source went back to the loaded revision, e.g. a
+			// rejected push was reverted: nothing is pending anymore
+			r.mu.Lock()
+			r.stale = false
+			r.mu.Unlock()
+			return false
+		}
+		return true
+	}
+	return stale
+}
+
+// Status returns the current refresh status.
+func (r *Refresher) Status() Status {
+	r.mu.Lock()
+	status := r.status
+	r.mu.Unlock()
+
+	status.Entries, status.Icons = r.store.Counts()
+	return status
+}
+
+// finish records the outcome of a refresh started at start.
+func (r *Refresher) finish(start time.Time, err error) {
+	r.record(err)
+
+	elapsed := time.Since(start)
+	for _, fn := range r.onRefresh {
+		fn(elapsed, err)
+	}
+}
+
+// recordPoll records a successful poll that found no changes. It does not
+// count as a refresh, so the OnRefresh hooks are not called.
+func (r *Refresher) recordPoll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.status.LastRefresh = now
+
+	// A pending load keeps its error and the docs are not current
+	if r.stale {
+		return
+	}
+	r.status.LastSuccess = now
+	r.status.LastError = ""
+}
+
+func (r *Refresher) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.status.LastRefresh = now
+
+	if err != nil {
+		r.status.LastError = err.Error()
+		return
+	}
+
+	r.status.Ready = true
+	r.status.LastSuccess = now
+	r.status.LastError = ""
+
+	if rev, ok := r.source.(revisioner); ok {
+		if commit, err := rev.Head(); err == nil {
+			r.status.Commit = commit
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir serves documentation straight from a local docs directory, e.g. a
+// vacano-ui checkout being edited. Changes are detected by polling file
+// sizes and modification times.
+type Dir struct {
+	path        string
+	iconsDir    string
+	fingerprint uint64
+}
+
+// NewDir serves the docs directory at path. iconsDir, if set, is where the
+// icon SVGs are read from instead of the checkout.
+func NewDir(path, iconsDir string) (*Dir, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve docs directory: %w", err)
+	}
+
+	return &Dir{path: abs, iconsDir: iconsDir}, nil
+}
+
+func (d *Dir) Init() error {
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return fmt.Errorf("docs directory not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("docs path is not a directory: %s", d.path)
+	}
+
+	log.Printf("Serving docs from %s", d.path)
+
+	fingerprint, err := d.scan()
+	if err != nil {
+		return err
+	}
+	d.fingerprint = fingerprint
+
+	return nil
+}
+
+// Update rescans the directory and reports whether any markdown file or
+// the VitePress config was added, removed or modified.
+func (d *Dir) Update() (bool, error) {
+	fingerprint, err := d.scan()
+	if err != nil {
+		return false, err
+	}
+
+	changed := fingerprint != d.fingerprint
+	d.fingerprint = fingerprint
+
+	return changed, nil
+}
+
+func (d *Dir) FetchDocs() (map[string]string, error) {
+	return readDocsDir(d.path)
+}
+
+func (d *Dir) FetchVitePressConfig() (string, error) {
+	return readVitePressConfig(d.path)
+}
+
+// FetchIconSVGs looks for the Lucide sources next to the docs directory,
+// i.e. in the vacano-ui checkout it belongs to, unless an icons directory
+// is configured.
+func (d *Dir) FetchIconSVGs() (map[string]string, error) {
+	return readIconSVGs(filepath.Dir(d.path), d.iconsDir)
+}
+
+// Cleanup is a no-op: the directory is not owned by the server.
+func (d *Dir) Cleanup() {}
+
+func (d *Dir) scan() (uint64, error) {
+	h := fnv.New64a()
+
+	err := filepath.WalkDir(d.path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "node_modules" || (entry.Name() == "cache" && strings.Contains(path, ".vitepress")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".md") && !strings.HasSuffix(path, "config.ts") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan docs directory: %w", err)
+	}
+
+	return h.Sum64(), nil
+}
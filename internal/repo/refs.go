@@ -0,0 +1,172 @@
+package repo
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+var commitSHARegex = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// Snapshot is the documentation of the repository at a single ref.
+type Snapshot struct {
+	Ref             string
+	Commit          string
+	Files           map[string]string
+	VitePressConfig string
+	Changelog       string
+}
+
+// ReadRef reads the docs, VitePress config and CHANGELOG.md of ref (a tag,
+// branch or commit) without touching the working tree. "HEAD" and the
+// served branch are read from the clone as checked out. Other refs are
+// fetched into a separate scratch repository: a shallow fetch into the clone
+// would turn the branch tip into a shallow commit and break every later pull.
+func (r *Repo) ReadRef(ref string) (*Snapshot, error) {
+	dir, commit, err := r.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// One archive of the docs tree instead of a git show per file
+	archive, err := git(dir, "archive", "--format=tar", commit, "--", "docs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docs at %s: %w", ref, err)
+	}
+
+	snapshot := &Snapshot{
+		Ref:    ref,
+		Commit: commit,
+		Files:  make(map[string]string),
+	}
+
+	tr := tar.NewReader(strings.NewReader(archive))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read docs archive at %s: %w", ref, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := header.Name
+		isConfig := name == "docs/.vitepress/config.ts"
+		if !isConfig && (!strings.HasSuffix(name, ".md") || strings.Contains(name, ".vitepress")) {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", name, ref, err)
+		}
+
+		if isConfig {
+			snapshot.VitePressConfig = string(content)
+		} else {
+			snapshot.Files[name] = string(content)
+		}
+	}
+
+	if len(snapshot.Files) == 0 {
+		return nil, fmt.Errorf("no docs found at %s", ref)
+	}
+
+	// Optional
+	snapshot.Changelog, _ = git(dir, "show", commit+":CHANGELOG.md")
+
+	return snapshot, nil
+}
+
+// resolveRef returns the repository ref is read from and its commit.
+func (r *Repo) resolveRef(ref string) (dir, commit string, err error) {
+	if ref == "HEAD" || ref == r.branch {
+		commit, err := r.Head()
+		return r.localPath, commit, err
+	}
+	if err := validateRef(ref); err != nil {
+		return "", "", err
+	}
+
+	r.scratchMu.Lock()
+	defer r.scratchMu.Unlock()
+
+	if err := r.initScratch(); err != nil {
+		return "", "", err
+	}
+
+	cmd := exec.Command("git", "-C", r.scratchPath, "fetch", "--depth", "1", r.url, "--end-of-options", ref)
+	r.setSSHEnv(cmd)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", "", fmt.Errorf("git fetch %s failed: %w, output: %s", ref, err, string(output))
+	}
+
+	commit, err = git(r.scratchPath, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.scratchPath, strings.TrimSpace(commit), nil
+}
+
+// initScratch creates the bare repository diff refs are fetched into, once.
+func (r *Repo) initScratch() error {
+	if r.scratchPath != "" {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "vacano-ui-refs-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	if output, err := exec.Command("git", "init", "--bare", "-q", dir).CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("git init failed: %w, output: %s", err, string(output))
+	}
+
+	r.scratchPath = dir
+	return nil
+}
+
+// validateRef rejects refs that are neither a commit SHA nor a valid branch
+// or tag name. Refs come from tool arguments and end up on the git command
+// line, so anything that could pass for an option must never get through.
+func validateRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref: %q", ref)
+	}
+	if commitSHARegex.MatchString(ref) {
+		return nil
+	}
+
+	if err := exec.Command("git", "check-ref-format", "--allow-onelevel", ref).Run(); err != nil {
+		return fmt.Errorf("invalid ref: %q", ref)
+	}
+
+	return nil
+}
+
+// git runs a read-only git command in dir and returns its stdout.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w, output: %s", args[0], err, stderr.String())
+	}
+
+	return string(output), nil
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/vacano-house/vacano-ui-mcp/internal/config"
 )
@@ -16,6 +17,15 @@ type Repo struct {
 	branch     string
 	sshKeyFile string
 	localPath  string
+	iconsDir   string
+
+	// mu serializes git commands that write to the clone (pull)
+	mu sync.Mutex
+
+	// scratchPath is the bare repository diff refs are fetched into,
+	// created on first use; scratchMu guards it
+	scratchMu   sync.Mutex
+	scratchPath string
 }
 
 func New(cfg config.RepoConfig) (*Repo, error) {
@@ -28,6 +38,7 @@ func New(cfg config.RepoConfig) (*Repo, error) {
 		url:       cfg.URL,
 		branch:    cfg.Branch,
 		localPath: tmpDir,
+		iconsDir:  cfg.IconsDir,
 	}
 
 	if cfg.SSHKey != "" {
@@ -65,6 +76,9 @@ func (r *Repo) Clone() error {
 }
 
 func (r *Repo) Pull() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	cmd := exec.Command("git", "-C", r.localPath, "pull", "--ff-only")
 	r.setSSHEnv(cmd)
 
@@ -77,64 +91,74 @@ func (r *Repo) Pull() error {
 	return nil
 }
 
-func (r *Repo) FetchDocs() (map[string]string, error) {
-	docsPath := filepath.Join(r.localPath, "docs")
+// Init clones the repository.
+func (r *Repo) Init() error {
+	return r.Clone()
+}
 
-	if _, err := os.Stat(docsPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("docs directory not found at %s", docsPath)
+// Update pulls the branch and reports whether HEAD moved. Tags are
+// checked out detached and never change, so they are not pulled.
+func (r *Repo) Update() (bool, error) {
+	if !r.onBranch() {
+		return false, nil
 	}
 
-	files := make(map[string]string)
-
-	err := filepath.Walk(docsPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
-			return nil
-		}
-
-		// Skip .vitepress directory
-		relPath, _ := filepath.Rel(r.localPath, path)
-		if strings.Contains(relPath, ".vitepress") {
-			return nil
-		}
+	before, err := r.Head()
+	if err != nil {
+		return false, err
+	}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read %s: %w", path, err)
-		}
+	if err := r.Pull(); err != nil {
+		return false, err
+	}
 
-		files[relPath] = string(content)
+	after, err := r.Head()
+	if err != nil {
+		return false, err
+	}
 
-		return nil
-	})
+	return before != after, nil
+}
 
+// Head returns the commit SHA currently checked out.
+func (r *Repo) Head() (string, error) {
+	output, err := exec.Command("git", "-C", r.localPath, "rev-parse", "HEAD").CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("failed to walk docs directory: %w", err)
+		return "", fmt.Errorf("git rev-parse failed: %w, output: %s", err, string(output))
 	}
 
-	return files, nil
+	return strings.TrimSpace(string(output)), nil
 }
 
-func (r *Repo) FetchVitePressConfig() (string, error) {
-	configPath := filepath.Join(r.localPath, "docs", ".vitepress", "config.ts")
+func (r *Repo) onBranch() bool {
+	return exec.Command("git", "-C", r.localPath, "symbolic-ref", "-q", "HEAD").Run() == nil
+}
 
-	content, err := os.ReadFile(configPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			log.Println("VitePress config not found, using default categories")
-			return "", nil
-		}
-		return "", fmt.Errorf("failed to read VitePress config: %w", err)
+func (r *Repo) FetchDocs() (map[string]string, error) {
+	return readDocsDir(filepath.Join(r.localPath, "docs"))
+}
+
+func (r *Repo) FetchVitePressConfig() (string, error) {
+	content, err := readVitePressConfig(filepath.Join(r.localPath, "docs"))
+	if err == nil && content == "" {
+		log.Println("VitePress config not found, using default categories")
 	}
+	return content, err
+}
 
-	return string(content), nil
+func (r *Repo) FetchIconSVGs() (map[string]string, error) {
+	return readIconSVGs(r.localPath, r.iconsDir)
 }
 
 func (r *Repo) Cleanup() {
 	os.RemoveAll(r.localPath)
+
+	r.scratchMu.Lock()
+	if r.scratchPath != "" {
+		os.RemoveAll(r.scratchPath)
+	}
+	r.scratchMu.Unlock()
+
 	if r.sshKeyFile != "" {
 		os.Remove(r.sshKeyFile)
 	}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source provides the raw markdown documentation.
+type Source interface {
+	// Init prepares the source (e.g. clones the repository).
+	Init() error
+	// Update fetches upstream changes and reports whether the docs changed.
+	Update() (bool, error)
+	// FetchDocs returns markdown files keyed by path relative to the repo
+	// root, e.g. docs/components/button.md.
+	FetchDocs() (map[string]string, error)
+	// FetchVitePressConfig returns docs/.vitepress/config.ts, or "" if absent.
+	FetchVitePressConfig() (string, error)
+	// FetchIconSVGs returns the Lucide icon SVGs keyed by file name without
+	// extension (e.g. trash-2), or nil if the sources are not present.
+	FetchIconSVGs() (map[string]string, error)
+	// Cleanup releases temporary files.
+	Cleanup()
+}
+
+// readDocsDir reads every markdown file under docsPath, skipping .vitepress.
+// Keys are prefixed with "docs/" regardless of the directory's own name.
+func readDocsDir(docsPath string) (map[string]string, error) {
+	if _, err := os.Stat(docsPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("docs directory not found at %s", docsPath)
+	}
+
+	files := make(map[string]string)
+
+	err := filepath.Walk(docsPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		// Skip .vitepress directory
+		relPath, _ := filepath.Rel(docsPath, path)
+		if strings.Contains(relPath, ".vitepress") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		files[filepath.ToSlash(filepath.Join("docs", relPath))] = string(content)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk docs directory: %w", err)
+	}
+
+	return files, nil
+}
+
+func readVitePressConfig(docsPath string) (string, error) {
+	configPath := filepath.Join(docsPath, ".vitepress", "config.ts")
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read VitePress config: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// iconDirs are the places, relative to the repository root, where the Lucide
+// icon SVGs can be found: an installed lucide-static package or a checkout
+// of the lucide sources.
+var iconDirs = []string{
+	"node_modules/lucide-static/icons",
+	"node_modules/lucide/icons",
+	"lucide/icons",
+}
+
+// readIconSVGs reads the SVG files of iconsDir when it is set, and of the
+// first icon directory present under root otherwise. A shallow clone has no
+// node_modules, so without iconsDir it usually returns nil.
+func readIconSVGs(root, iconsDir string) (map[string]string, error) {
+	if iconsDir != "" {
+		return readSVGDir(iconsDir)
+	}
+
+	for _, dir := range iconDirs {
+		path := filepath.Join(root, dir)
+
+		svgs, err := readSVGDir(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(svgs) > 0 {
+			return svgs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// readSVGDir reads the .svg files of path by file name without extension.
+func readSVGDir(path string) (map[string]string, error) {
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read icon directory %s: %w", path, err)
+	}
+
+	svgs := make(map[string]string)
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".svg")
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read icon %s: %w", entry.Name(), err)
+		}
+		svgs[name] = string(content)
+	}
+
+	return svgs, nil
+}
@@ -0,0 +1,232 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+const (
+	Scheme = "vacano-ui"
+
+	IconsURI = Scheme + "://icons"
+
+	mimeMarkdown = "text/markdown"
+	mimeJSON     = "application/json"
+)
+
+// groups are the docs subdirectories published as resources.
+var groups = []string{"components", "lib", "guide"}
+
+// Publisher exposes store entries as MCP resources and keeps the server's
+// resource list in sync with the store.
+type Publisher struct {
+	server *mcp.Server
+	store  *docs.Store
+
+	mu        sync.Mutex
+	resources map[string]*mcp.Resource
+}
+
+func NewPublisher(server *mcp.Server, store *docs.Store) *Publisher {
+	p := &Publisher{
+		server:    server,
+		store:     store,
+		resources: make(map[string]*mcp.Resource),
+	}
+
+	for _, group := range groups {
+		server.AddResourceTemplate(&mcp.ResourceTemplate{
+			Name:        group,
+			Title:       fmt.Sprintf("vacano-ui %s docs", group),
+			Description: fmt.Sprintf("Documentation page from docs/%s by slug, the path below it without .md", group),
+			URITemplate: fmt.Sprintf("%s://%s/{+slug}", Scheme, group),
+			MIMEType:    mimeMarkdown,
+		}, p.readEntry)
+	}
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "icon",
+		Title:       "vacano-ui icon",
+		Description: "Icon metadata by name",
+		URITemplate: IconsURI + "/{name}",
+		MIMEType:    mimeJSON,
+	}, p.readIcon)
+
+	return p
+}
+
+// Sync updates the published resources to the current store contents. Only
+// resources that were added, removed or whose metadata changed are touched,
+// so a reload that changes nothing sends no resources/list_changed
+// notification.
+func (p *Publisher) Sync() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := make(map[string]*mcp.Resource)
+
+	for _, entry := range p.store.Entries() {
+		uri := EntryURI(entry)
+		if uri == "" {
+			continue
+		}
+
+		current[uri] = &mcp.Resource{
+			URI:         uri,
+			Name:        entry.Name,
+			Title:       entry.Name,
+			Description: entry.Description,
+			MIMEType:    mimeMarkdown,
+			Size:        int64(len(entry.Content)),
+		}
+	}
+
+	current[IconsURI] = &mcp.Resource{
+		URI:         IconsURI,
+		Name:        "icons",
+		Title:       "vacano-ui icons",
+		Description: "Catalogue of all icons exported from @vacano/ui/icons, grouped by category",
+		MIMEType:    mimeMarkdown,
+	}
+
+	for uri, resource := range current {
+		if old, ok := p.resources[uri]; ok && sameResource(old, resource) {
+			continue
+		}
+
+		handler := p.readEntry
+		if uri == IconsURI {
+			handler = p.readIcons
+		}
+		p.server.AddResource(resource, handler)
+	}
+
+	var stale []string
+	for uri := range p.resources {
+		if current[uri] == nil {
+			stale = append(stale, uri)
+		}
+	}
+	if len(stale) > 0 {
+		p.server.RemoveResources(stale...)
+	}
+
+	p.resources = current
+}
+
+// sameResource reports whether two resources have the same metadata.
+func sameResource(a, b *mcp.Resource) bool {
+	return a.Name == b.Name &&
+		a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.MIMEType == b.MIMEType &&
+		a.Size == b.Size
+}
+
+// EntryURI returns the resource URI for an entry from its path below docs/,
+// e.g. vacano-ui://components/button for docs/components/button.md and
+// vacano-ui://components/forms/date-picker for a nested page. Pages at the
+// top of docs/ have no group and no URI.
+func EntryURI(entry docs.DocEntry) string {
+	rel, ok := strings.CutPrefix(path.Clean(entry.Path), "docs/")
+	if !ok {
+		return ""
+	}
+	group, slug, ok := strings.Cut(strings.TrimSuffix(rel, ".md"), "/")
+	if !ok || group == "" || slug == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s/%s", Scheme, group, slug)
+}
+
+// entryPath is the inverse of EntryURI: the docs path an entry URI points at.
+func entryPath(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, Scheme+"://")
+	if !ok || rest == "" {
+		return "", false
+	}
+	return "docs/" + rest + ".md", true
+}
+
+func (p *Publisher) readEntry(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+
+	docPath, ok := entryPath(uri)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	entry := p.store.GetByPath(docPath)
+	if entry == nil || EntryURI(*entry) != uri {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: mimeMarkdown, Text: entry.Content}},
+	}, nil
+}
+
+func (p *Publisher) readIcons(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	icons := p.store.Icons()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Icons\n\n%d icons. Import from `@vacano/ui/icons`.\n", len(icons)))
+
+	currentCategory := ""
+	for _, icon := range icons {
+		if icon.Category != currentCategory {
+			sb.WriteString(fmt.Sprintf("\n## %s\n\n", icon.Category))
+			currentCategory = icon.Category
+		}
+		sb.WriteString(fmt.Sprintf("- `%s` — %s\n", icon.Name, icon.Description))
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: req.Params.URI, MIMEType: mimeMarkdown, Text: sb.String()}},
+	}, nil
+}
+
+func (p *Publisher) readIcon(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+
+	group, name, ok := splitURI(uri)
+	if !ok || group != "icons" {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	icon := p.store.GetIcon(name)
+	if icon == nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	data, err := json.Marshal(icon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: mimeJSON, Text: string(data)}},
+	}, nil
+}
+
+// splitURI splits vacano-ui://<group>/<name> into its parts.
+func splitURI(uri string) (group, name string, ok bool) {
+	rest, found := strings.CutPrefix(uri, Scheme+"://")
+	if !found {
+		return "", "", false
+	}
+
+	group, name, found = strings.Cut(rest, "/")
+	if !found || group == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+
+	return group, name, true
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+// trimNoteTokens is kept free of max_tokens for the note on what was left
+// out.
+const trimNoteTokens = 80
+
+// contentBudget is the part of maxTokens available to the content itself.
+func contentBudget(maxTokens int) int {
+	return max(maxTokens-trimNoteTokens, maxTokens/2)
+}
+
+// fitContent trims markdown to maxTokens, or returns it unchanged when
+// maxTokens is 0. prefix is the heading path the content sits under.
+func fitContent(content string, maxTokens int, prefix string) (string, []string) {
+	if maxTokens <= 0 {
+		return content, nil
+	}
+	return docs.FitTokens(content, contentBudget(maxTokens), prefix)
+}
+
+// trimNote lists the sections left out of a component page and how to get
+// them.
+func trimNote(maxTokens int, component string, omitted []string) string {
+	if len(omitted) == 0 {
+		return ""
+	}
+
+	var sections []string
+	for _, path := range omitted {
+		if !strings.HasPrefix(path, "(") {
+			sections = append(sections, fmt.Sprintf("`%s`", path))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n\n---\n\n> Trimmed to fit max_tokens=%d.", maxTokens))
+	if len(sections) > 0 {
+		sb.WriteString(fmt.Sprintf(" Left out: %s.", strings.Join(sections, ", ")))
+		sb.WriteString(fmt.Sprintf(" Fetch one with get_component_section (name: %q, section: <path>)", component))
+		sb.WriteString(" or call again with a larger max_tokens.\n")
+	} else {
+		sb.WriteString(" The end of the page was cut; call again with a larger max_tokens.\n")
+	}
+
+	return sb.String()
+}
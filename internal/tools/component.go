@@ -2,35 +2,133 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
 )
 
+// Misspellings within this edit distance resolve to the closest component
+// when it is the only candidate that close and the name is at least
+// minAutoCorrectLength runes long. Short names are too close to each other
+// (Tag and Tab) to guess; they get a list of suggestions instead.
+const (
+	autoCorrectDistance  = 1
+	minAutoCorrectLength = 6
+)
+
+const maxSuggestions = 5
+
 type GetComponentParams struct {
-	Name string `json:"name" jsonschema:"Exact component name (e.g. Button, Modal, DatePicker)"`
+	Name      string `json:"name" jsonschema:"Component name (e.g. Button, Modal, DatePicker). Case and hyphens are ignored"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional approximate token budget for the response. When the docs are longer, lower-priority sections are left out (long examples first, props tables last) and a note lists what was omitted"`
+	Version   string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+type ComponentResult struct {
+	Name        string        `json:"name"`
+	Category    docs.Category `json:"category"`
+	Description string        `json:"description"`
+	Path        string        `json:"path"`
+	Content     string        `json:"content"`
+	Sections    []string      `json:"sections"`
+	Omitted     []string      `json:"omitted,omitempty"`
 }
 
-func NewGetComponentHandler(store *docs.Store) func(context.Context, *mcp.CallToolRequest, *GetComponentParams) (*mcp.CallToolResult, any, error) {
-	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetComponentParams) (*mcp.CallToolResult, any, error) {
+func NewGetComponentHandler(versions *docs.Versions, onEmpty EmptyResultFunc) func(context.Context, *mcp.CallToolRequest, *GetComponentParams) (*mcp.CallToolResult, *ComponentResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetComponentParams) (*mcp.CallToolResult, *ComponentResult, error) {
 		if params.Name == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{&mcp.TextContent{Text: "name parameter is required"}},
-				IsError: true,
-			}, nil, nil
+			return nil, nil, errors.New("name parameter is required")
+		}
+
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry, note, err := resolveComponent(store, params.Name)
+		if err != nil {
+			onEmpty.report(params.Name)
+			return nil, nil, err
 		}
 
-		entry := store.GetByName(params.Name)
+		content, omitted := fitContent(entry.Content, params.MaxTokens, "")
 
-		if entry == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Component not found: %s", params.Name)}},
-			}, nil, nil
+		result := &ComponentResult{
+			Name:        entry.Name,
+			Category:    entry.Category,
+			Description: entry.Description,
+			Path:        entry.Path,
+			Content:     content,
+			Sections:    nonNil(entry.SectionPaths()),
+			Omitted:     omitted,
 		}
 
+		text := note + content + trimNote(params.MaxTokens, entry.Name, omitted)
+
 		return &mcp.CallToolResult{
-			Content: []mcp.Content{&mcp.TextContent{Text: entry.Content}},
-		}, nil, nil
+			Content: []mcp.Content{&mcp.TextContent{Text: text}},
+		}, result, nil
+	}
+}
+
+// resolveComponent looks up an entry by name, falling back to the closest
+// misspelling. note is non-empty when a correction was applied; the error,
+// listing suggestions, is returned as the tool error when nothing matched.
+func resolveComponent(store *docs.Store, name string) (entry *docs.DocEntry, note string, err error) {
+	if entry := store.GetByName(name); entry != nil {
+		return entry, "", nil
+	}
+
+	suggestions := store.Suggest(name, maxSuggestions)
+
+	if closest := closestMatch(name, suggestions); closest != "" {
+		if entry := store.GetByName(closest); entry != nil {
+			note := fmt.Sprintf("> Showing %s (closest match for \"%s\").\n\n", entry.Name, name)
+			return entry, note, nil
+		}
+	}
+
+	return nil, "", errors.New(notFoundMessage(name, suggestions))
+}
+
+// closestMatch returns the single suggestion within autoCorrectDistance of
+// name, or "" when name is too short or there is not exactly one.
+func closestMatch(name string, suggestions []docs.NameMatch) string {
+	if utf8.RuneCountInString(name) < minAutoCorrectLength {
+		return ""
+	}
+	if len(suggestions) == 0 || suggestions[0].Distance > autoCorrectDistance {
+		return ""
+	}
+	if len(suggestions) > 1 && suggestions[1].Distance <= autoCorrectDistance {
+		return ""
+	}
+	return suggestions[0].Name
+}
+
+func notFoundMessage(name string, suggestions []docs.NameMatch) string {
+	msg := fmt.Sprintf("Component not found: %s", name)
+	if len(suggestions) == 0 {
+		return msg
+	}
+
+	names := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		names = append(names, s.Name)
+	}
+
+	return fmt.Sprintf("%s\n\nDid you mean: %s?", msg, strings.Join(names, ", "))
+}
+
+// nonNil turns a nil slice into an empty one, so it is encoded as [] rather
+// than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
 	}
+	return items
 }
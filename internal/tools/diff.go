@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+	"github.com/vacano-house/vacano-ui-mcp/internal/repo"
+)
+
+type DiffVersionsParams struct {
+	From      string `json:"from" jsonschema:"Older git ref to compare from: tag, branch or commit (e.g. v1.2.0)"`
+	To        string `json:"to,omitempty" jsonschema:"Newer git ref to compare to. Defaults to the currently served branch"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional approximate token budget for the response. When the diff is longer, the longest sections (usually the changelog and the biggest component changes) are left out and a note lists them"`
+}
+
+type DiffResult struct {
+	From       string                 `json:"from"`
+	FromCommit string                 `json:"from_commit"`
+	To         string                 `json:"to"`
+	ToCommit   string                 `json:"to_commit"`
+	Added      []string               `json:"added"`
+	Removed    []string               `json:"removed"`
+	Changed    []docs.ComponentChange `json:"changed"`
+	Changelog  string                 `json:"changelog,omitempty"`
+	Omitted    []string               `json:"omitted,omitempty"`
+}
+
+func NewDiffVersionsHandler(repository *repo.Repo) func(context.Context, *mcp.CallToolRequest, *DiffVersionsParams) (*mcp.CallToolResult, *DiffResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *DiffVersionsParams) (*mcp.CallToolResult, *DiffResult, error) {
+		if params.From == "" {
+			return nil, nil, errors.New("from parameter is required")
+		}
+		to := params.To
+		if to == "" {
+			to = "HEAD"
+		}
+
+		before, err := repository.ReadRef(params.From)
+		if err != nil {
+			return nil, nil, err
+		}
+		after, err := repository.ReadRef(to)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		diff := docs.Diff(parseSnapshot(before), parseSnapshot(after))
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("# Changes from %s (%s) to %s (%s)\n\n", params.From, shortSHA(before.Commit), to, shortSHA(after.Commit)))
+		writeDiff(&sb, diff)
+
+		changelog := docs.ChangelogBetween(after.Changelog, params.From, params.To)
+		if changelog != "" {
+			sb.WriteString(changelogHeading)
+			sb.WriteString(shiftHeadings(changelog, 1))
+			sb.WriteString("\n")
+		}
+
+		content, omitted := fitContent(sb.String(), params.MaxTokens, "")
+		if len(omitted) > 0 {
+			diff, changelog = trimDiff(diff, content, omitted)
+			content += fmt.Sprintf("\n\n---\n\n> Trimmed to fit max_tokens=%d. Left out: `%s`. Call again with a larger max_tokens.\n", params.MaxTokens, strings.Join(omitted, "`, `"))
+		}
+
+		result := &DiffResult{
+			From:       params.From,
+			FromCommit: before.Commit,
+			To:         to,
+			ToCommit:   after.Commit,
+			Added:      nonNil(diff.Added),
+			Removed:    nonNil(diff.Removed),
+			Changed:    nonNil(diff.Changed),
+			Changelog:  changelog,
+			Omitted:    omitted,
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: content}},
+		}, result, nil
+	}
+}
+
+// changelogHeading starts the changelog section of the diff text.
+const changelogHeading = "## CHANGELOG.md\n\n"
+
+// trimDiff drops the components whose sections were left out of the trimmed
+// text, and returns the changelog as kept in it, so the structured content
+// matches the text.
+func trimDiff(diff docs.DocsDiff, content string, omitted []string) (docs.DocsDiff, string) {
+	left := make(map[string]bool, len(omitted))
+	for _, path := range omitted {
+		left[path] = true
+	}
+
+	if left["Added"] {
+		diff.Added = nil
+	}
+	if left["Removed"] {
+		diff.Removed = nil
+	}
+	changelog := ""
+	if _, kept, ok := strings.Cut(content, changelogHeading); ok {
+		changelog = shiftHeadings(strings.TrimSpace(kept), -1)
+	}
+
+	var changed []docs.ComponentChange
+	for _, change := range diff.Changed {
+		if !left["Changed/"+change.Name] {
+			changed = append(changed, change)
+		}
+	}
+	diff.Changed = changed
+
+	return diff, changelog
+}
+
+func parseSnapshot(snapshot *repo.Snapshot) []docs.DocEntry {
+	return docs.Parse(snapshot.Files, docs.ParseCategories(snapshot.VitePressConfig))
+}
+
+func writeDiff(sb *strings.Builder, diff docs.DocsDiff) {
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		sb.WriteString("No documentation changes.\n\n")
+		return
+	}
+
+	if len(diff.Added) > 0 {
+		sb.WriteString("## Added\n\n")
+		for _, name := range diff.Added {
+			sb.WriteString(fmt.Sprintf("- **%s**\n", name))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(diff.Removed) > 0 {
+		sb.WriteString("## Removed\n\n")
+		for _, name := range diff.Removed {
+			sb.WriteString(fmt.Sprintf("- **%s**\n", name))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(diff.Changed) > 0 {
+		sb.WriteString("## Changed\n\n")
+		for _, change := range diff.Changed {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", change.Name))
+			if change.DescriptionChanged() {
+				sb.WriteString(fmt.Sprintf("- Description: \"%s\" → \"%s\"\n", change.DescriptionBefore, change.DescriptionAfter))
+			}
+			for _, prop := range change.AddedProps {
+				sb.WriteString(fmt.Sprintf("- Added prop `%s`: `%s`\n", prop.Name, prop.Type))
+			}
+			for _, prop := range change.RemovedProps {
+				sb.WriteString(fmt.Sprintf("- Removed prop `%s`\n", prop.Name))
+			}
+			for _, pc := range change.ChangedProps {
+				sb.WriteString(fmt.Sprintf("- Changed prop `%s`:%s\n", pc.Name, propChangeSummary(pc)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// shiftHeadings moves the markdown headings of text down (positive by) or
+// up (negative by) that many levels, so changelog entries nest under the
+// CHANGELOG.md heading of the diff.
+func shiftHeadings(text string, by int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+		if by > 0 {
+			lines[i] = strings.Repeat("#", by) + line
+		} else {
+			lines[i] = strings.TrimPrefix(line, strings.Repeat("#", -by))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func propChangeSummary(pc docs.PropChange) string {
+	var parts []string
+	if pc.Before.Type != pc.After.Type {
+		parts = append(parts, fmt.Sprintf(" type `%s` → `%s`", pc.Before.Type, pc.After.Type))
+	}
+	if pc.Before.Default != pc.After.Default {
+		parts = append(parts, fmt.Sprintf(" default `%s` → `%s`", pc.Before.Default, pc.After.Default))
+	}
+	if pc.Before.Required != pc.After.Required {
+		parts = append(parts, fmt.Sprintf(" required %t → %t", pc.Before.Required, pc.After.Required))
+	}
+	if pc.Before.Description != pc.After.Description {
+		parts = append(parts, " description updated")
+	}
+	return strings.Join(parts, ";")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+type GetExamplesParams struct {
+	Name      string `json:"name" jsonschema:"Component name (e.g. Button, Modal, DatePicker)"`
+	Keyword   string `json:"keyword,omitempty" jsonschema:"Optional filter matched against the example heading, caption and code (e.g. 'controlled', 'with icon')"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional approximate token budget for the response. When the examples are longer, the longest ones are left out and a note lists them"`
+	Version   string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+type ExamplesResult struct {
+	Component string         `json:"component"`
+	Keyword   string         `json:"keyword,omitempty"`
+	Examples  []docs.Example `json:"examples"`
+	Omitted   []string       `json:"omitted,omitempty"`
+}
+
+func NewGetExamplesHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *GetExamplesParams) (*mcp.CallToolResult, *ExamplesResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetExamplesParams) (*mcp.CallToolResult, *ExamplesResult, error) {
+		if params.Name == "" {
+			return nil, nil, errors.New("name parameter is required")
+		}
+
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry, note, err := resolveComponent(store, params.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		examples := []docs.Example{}
+		for _, ex := range entry.Examples {
+			if params.Keyword == "" || ex.MatchesKeyword(params.Keyword) {
+				examples = append(examples, ex)
+			}
+		}
+
+		total := len(examples)
+		var omitted []string
+		if params.MaxTokens > 0 {
+			examples, omitted = fitExamples(entry, examples, contentBudget(params.MaxTokens))
+		}
+
+		result := &ExamplesResult{Component: entry.Name, Keyword: params.Keyword, Examples: examples, Omitted: omitted}
+
+		if total == 0 {
+			msg := fmt.Sprintf("No examples found for %s", entry.Name)
+			if params.Keyword != "" {
+				msg = fmt.Sprintf("No examples found for %s matching: %s", entry.Name, params.Keyword)
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: note + msg}},
+			}, result, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(note)
+		sb.WriteString(fmt.Sprintf("Found %d example(s) for %s:\n\n", total, entry.Name))
+
+		for _, ex := range examples {
+			sb.WriteString(renderExample(entry, ex))
+		}
+
+		if len(omitted) > 0 {
+			sb.WriteString(fmt.Sprintf("---\n\n> Trimmed to fit max_tokens=%d. Left out %d example(s): `%s`.", params.MaxTokens, len(omitted), strings.Join(omitted, "`, `")))
+			sb.WriteString(" Narrow them down with keyword or call again with a larger max_tokens.\n")
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, result, nil
+	}
+}
+
+func renderExample(entry *docs.DocEntry, ex docs.Example) string {
+	title := ex.Path
+	if title == "" {
+		title = entry.Name
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+	if ex.Caption != "" {
+		sb.WriteString(ex.Caption)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", ex.Language, ex.Code))
+
+	return sb.String()
+}
+
+// fitExamples drops the longest examples until the rest fit maxTokens,
+// keeping document order. It returns the kept examples and the titles of
+// the dropped ones.
+func fitExamples(entry *docs.DocEntry, examples []docs.Example, maxTokens int) ([]docs.Example, []string) {
+	tokens := make([]int, len(examples))
+	total := 0
+	for i, ex := range examples {
+		tokens[i] = docs.EstimateTokens(renderExample(entry, ex))
+		total += tokens[i]
+	}
+
+	order := make([]int, len(examples))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return tokens[order[i]] > tokens[order[j]]
+	})
+
+	dropped := make([]bool, len(examples))
+	for _, i := range order {
+		if total <= maxTokens {
+			break
+		}
+		dropped[i] = true
+		total -= tokens[i]
+	}
+
+	kept := []docs.Example{}
+	var omitted []string
+	for i, ex := range examples {
+		if !dropped[i] {
+			kept = append(kept, ex)
+			continue
+		}
+		title := ex.Path
+		if title == "" {
+			title = entry.Name
+		}
+		omitted = append(omitted, title)
+	}
+
+	return kept, omitted
+}
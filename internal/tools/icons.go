@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,42 +10,184 @@ import (
 	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
 )
 
+// iconsImport is the package vacano-ui icons are imported from.
+const iconsImport = "@vacano/ui/icons"
+
 type SearchIconsParams struct {
-	Query string `json:"query" jsonschema:"Search query to find icons by name, description, or category (e.g. 'arrow', 'close', 'navigation', 'chart')"`
+	Query    string `json:"query,omitempty" jsonschema:"Search query to find icons by name, description, or category (e.g. 'arrow', 'close', 'delete', 'settings'). Exact names rank first and common synonyms match (delete finds Trash). Optional when category is given"`
+	Category string `json:"category,omitempty" jsonschema:"Optional icon category to search in (see list_icon_categories), e.g. 'Arrows and Chevrons'"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum number of icons to return (default 50, max 200)"`
+	Offset   int    `json:"offset,omitempty" jsonschema:"Number of icons to skip, for fetching the next page (see next_offset)"`
+	Version  string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
 }
 
-func NewSearchIconsHandler(store *docs.Store) func(context.Context, *mcp.CallToolRequest, *SearchIconsParams) (*mcp.CallToolResult, any, error) {
-	return func(_ context.Context, _ *mcp.CallToolRequest, params *SearchIconsParams) (*mcp.CallToolResult, any, error) {
-		if params.Query == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{&mcp.TextContent{Text: "query parameter is required"}},
-				IsError: true,
-			}, nil, nil
+type IconsResult struct {
+	Query    string           `json:"query,omitempty"`
+	Category string           `json:"category,omitempty"`
+	Import   string           `json:"import"`
+	Icons    []docs.IconEntry `json:"icons"`
+	Page
+}
+
+func NewSearchIconsHandler(versions *docs.Versions, onEmpty EmptyResultFunc) func(context.Context, *mcp.CallToolRequest, *SearchIconsParams) (*mcp.CallToolResult, *IconsResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *SearchIconsParams) (*mcp.CallToolResult, *IconsResult, error) {
+		if params.Query == "" && params.Category == "" {
+			return nil, nil, errors.New("query or category parameter is required")
 		}
 
-		results := store.SearchIcons(params.Query)
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results, page := paginate(store.SearchIcons(params.Query, params.Category), params.Offset, params.Limit, defaultIconsLimit, maxIconsLimit)
 
-		if len(results) == 0 {
+		result := &IconsResult{Query: params.Query, Category: params.Category, Import: iconsImport, Icons: nonNil(results), Page: page}
+
+		subject := fmt.Sprintf("\"%s\"", params.Query)
+		switch {
+		case params.Query == "":
+			subject = fmt.Sprintf("category %s", params.Category)
+		case params.Category != "":
+			subject = fmt.Sprintf("\"%s\" in category %s", params.Query, params.Category)
+		}
+
+		if page.Total == 0 {
+			logged := params.Query
+			if params.Category != "" {
+				logged = strings.TrimSpace(logged + " category:" + params.Category)
+			}
+			onEmpty.report(logged)
 			return &mcp.CallToolResult{
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No icons found for: %s", params.Query)}},
-			}, nil, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No icons found for %s", subject)}},
+			}, result, nil
 		}
 
 		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("Found %d icon(s) for \"%s\".\n", len(results), params.Query))
-		sb.WriteString("Import from `@vacano/ui/icons`.\n\n")
+		found := fmt.Sprintf("Found %d icon(s) for %s", page.Total, subject)
+		sb.WriteString(page.header(found) + ", best match first.\n")
+		sb.WriteString(fmt.Sprintf("Import from `%s`.\n\n", iconsImport))
 
-		currentCategory := ""
 		for _, icon := range results {
-			if icon.Category != currentCategory {
-				sb.WriteString(fmt.Sprintf("### %s\n\n", icon.Category))
-				currentCategory = icon.Category
-			}
-			sb.WriteString(fmt.Sprintf("- `%s` — %s\n", icon.Name, icon.Description))
+			sb.WriteString(fmt.Sprintf("- `%s` [%s] — %s\n", icon.Name, icon.Category, icon.Description))
+		}
+		sb.WriteString(page.footer())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, result, nil
+	}
+}
+
+type ListIconCategoriesParams struct {
+	Version string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+type IconCategoriesResult struct {
+	Categories []docs.IconCategory `json:"categories"`
+}
+
+func NewListIconCategoriesHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *ListIconCategoriesParams) (*mcp.CallToolResult, *IconCategoriesResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *ListIconCategoriesParams) (*mcp.CallToolResult, *IconCategoriesResult, error) {
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		categories := store.IconCategories()
+		result := &IconCategoriesResult{Categories: nonNil(categories)}
+
+		if len(categories) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: "No icon categories found"}},
+			}, result, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%d icon categories. Pass one as the category of search_icons.\n\n", len(categories)))
+		for _, category := range categories {
+			sb.WriteString(fmt.Sprintf("- %s (%d icons)\n", category.Name, category.Count))
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
-		}, nil, nil
+		}, result, nil
 	}
 }
+
+type GetIconParams struct {
+	Name    string `json:"name" jsonschema:"Icon name as listed by search_icons (e.g. Trash2, ChevronDown). Case and hyphens are ignored, so trash-2 works too"`
+	Version string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+type IconResult struct {
+	docs.IconEntry
+	Import   string   `json:"import"`
+	Usage    string   `json:"usage"`
+	SVG      string   `json:"svg,omitempty"`
+	Elements []string `json:"elements,omitempty"`
+}
+
+func NewGetIconHandler(versions *docs.Versions, onEmpty EmptyResultFunc) func(context.Context, *mcp.CallToolRequest, *GetIconParams) (*mcp.CallToolResult, *IconResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetIconParams) (*mcp.CallToolResult, *IconResult, error) {
+		if params.Name == "" {
+			return nil, nil, errors.New("name parameter is required")
+		}
+
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		icon := store.GetIcon(params.Name)
+		if icon == nil {
+			onEmpty.report(params.Name)
+			return nil, nil, errors.New(iconNotFoundMessage(store, params.Name))
+		}
+
+		svg := store.IconSVG(icon.Name)
+		result := &IconResult{
+			IconEntry: *icon,
+			Import:    fmt.Sprintf("import { %s } from '%s'", icon.Name, iconsImport),
+			Usage:     fmt.Sprintf("<%s size={16} />", icon.Name),
+			SVG:       svg,
+			Elements:  docs.SVGElements(svg),
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("# %s\n\n", icon.Name))
+		sb.WriteString(fmt.Sprintf("%s. Category: %s.\n\n", strings.TrimSuffix(icon.Description, "."), icon.Category))
+		sb.WriteString(fmt.Sprintf("```tsx\n%s\n\n%s\n```\n", result.Import, result.Usage))
+
+		if svg == "" {
+			sb.WriteString("\nThe SVG source is not available on this server.\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("\n## SVG\n\n```svg\n%s\n```\n", svg))
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, result, nil
+	}
+}
+
+// iconNotFoundMessage suggests the best search matches for an unknown icon
+// name.
+func iconNotFoundMessage(store *docs.Store, name string) string {
+	msg := fmt.Sprintf("Icon not found: %s", name)
+
+	matches := store.SearchIcons(name, "")
+	if len(matches) == 0 {
+		return msg + "\n\nUse search_icons to find icons by keyword."
+	}
+
+	names := make([]string, 0, maxSuggestions)
+	for _, icon := range matches {
+		if len(names) == maxSuggestions {
+			break
+		}
+		names = append(names, icon.Name)
+	}
+
+	return fmt.Sprintf("%s\n\nDid you mean: %s?", msg, strings.Join(names, ", "))
+}
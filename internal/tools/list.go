@@ -11,24 +11,41 @@ import (
 
 type ListParams struct {
 	Category string `json:"category,omitempty" jsonschema:"Optional filter by category: form, data-display, feedback, layout, navigation, utility, overview, guide"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum number of components to return (default 100, max 200)"`
+	Offset   int    `json:"offset,omitempty" jsonschema:"Number of components to skip, for fetching the next page (see next_offset)"`
+	Version  string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
 }
 
-func NewListHandler(store *docs.Store) func(context.Context, *mcp.CallToolRequest, *ListParams) (*mcp.CallToolResult, any, error) {
-	return func(_ context.Context, _ *mcp.CallToolRequest, params *ListParams) (*mcp.CallToolResult, any, error) {
-		results := store.List(params.Category)
+type ListResult struct {
+	Category   string                 `json:"category,omitempty"`
+	Components []docs.DocEntrySummary `json:"components"`
+	Page
+}
+
+func NewListHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *ListParams) (*mcp.CallToolResult, *ListResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *ListParams) (*mcp.CallToolResult, *ListResult, error) {
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results, page := paginate(store.List(params.Category), params.Offset, params.Limit, defaultListLimit, maxListLimit)
+
+		result := &ListResult{Category: params.Category, Components: nonNil(results), Page: page}
 
-		if len(results) == 0 {
+		if page.Total == 0 {
 			msg := "No components found"
 			if params.Category != "" {
 				msg = fmt.Sprintf("No components found in category: %s", params.Category)
 			}
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{&mcp.TextContent{Text: msg}},
-			}, nil, nil
+			}, result, nil
 		}
 
 		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("Found %d component(s):\n\n", len(results)))
+		found := fmt.Sprintf("Found %d component(s)", page.Total)
+		sb.WriteString(page.header(found) + ":\n\n")
 
 		currentCategory := ""
 		for _, entry := range results {
@@ -39,9 +56,10 @@ func NewListHandler(store *docs.Store) func(context.Context, *mcp.CallToolReques
 			}
 			sb.WriteString(fmt.Sprintf("- **%s** — %s\n", entry.Name, entry.Description))
 		}
+		sb.WriteString(page.footer())
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
-		}, nil, nil
+		}, result, nil
 	}
 }
@@ -0,0 +1,70 @@
+package tools
+
+import "fmt"
+
+// Default and maximum page sizes, chosen so a page of results stays within
+// a few thousand tokens.
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	defaultIconsLimit  = 50
+	maxIconsLimit      = 200
+	defaultListLimit   = 100
+	maxListLimit       = 200
+)
+
+// Page describes which slice of the full result list a response holds.
+// NextOffset is 0 on the last page.
+type Page struct {
+	Total      int `json:"total"`
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	NextOffset int `json:"next_offset,omitempty"`
+}
+
+// paginate returns the page of items at offset. A limit of 0 selects
+// defaultLimit; larger limits are capped at maxLimit.
+func paginate[T any](items []T, offset, limit, defaultLimit, maxLimit int) ([]T, Page) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	offset = max(offset, 0)
+
+	page := Page{Total: len(items), Offset: offset, Limit: limit}
+	if offset >= len(items) {
+		return nonNil[T](nil), page
+	}
+
+	end := min(offset+limit, len(items))
+	if end < len(items) {
+		page.NextOffset = end
+	}
+
+	return items[offset:end], page
+}
+
+// header reports the total and which results are shown, e.g. "Found 37
+// result(s) for "date", showing 1-10".
+func (p Page) header(found string) string {
+	if p.Total == 0 || (p.Offset == 0 && p.NextOffset == 0) {
+		return found
+	}
+	if p.Offset >= p.Total {
+		return fmt.Sprintf("%s, none past offset %d", found, p.Offset)
+	}
+
+	end := p.Total
+	if p.NextOffset > 0 {
+		end = p.NextOffset
+	}
+	return fmt.Sprintf("%s, showing %d-%d", found, p.Offset+1, end)
+}
+
+// footer tells the model how to fetch the next page, if there is one.
+func (p Page) footer() string {
+	if p.NextOffset == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n%d more. Pass offset=%d for the next page.\n", p.Total-p.NextOffset, p.NextOffset)
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+type GetPropsParams struct {
+	Name      string `json:"name" jsonschema:"Component name (e.g. Button, Modal, DatePicker)"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional approximate token budget for the response. When the props are longer, the last ones are left out and a note lists them"`
+	Version   string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+type PropsResult struct {
+	Component string      `json:"component"`
+	Props     []docs.Prop `json:"props"`
+	Omitted   []string    `json:"omitted,omitempty"`
+}
+
+func NewGetPropsHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *GetPropsParams) (*mcp.CallToolResult, *PropsResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetPropsParams) (*mcp.CallToolResult, *PropsResult, error) {
+		if params.Name == "" {
+			return nil, nil, errors.New("name parameter is required")
+		}
+
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry, note, err := resolveComponent(store, params.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		props := nonNil(entry.Props)
+		var omitted []string
+		if params.MaxTokens > 0 {
+			props, omitted = fitProps(props, contentBudget(params.MaxTokens))
+		}
+
+		result := &PropsResult{
+			Component: entry.Name,
+			Props:     props,
+			Omitted:   omitted,
+		}
+
+		var sb strings.Builder
+		sb.WriteString(note)
+		if err := encodeJSON(&sb, result); err != nil {
+			return nil, nil, err
+		}
+
+		if len(omitted) > 0 {
+			sb.WriteString(fmt.Sprintf("\n> Trimmed to fit max_tokens=%d. Left out %d prop(s): `%s`.", params.MaxTokens, len(omitted), strings.Join(omitted, "`, `")))
+			sb.WriteString(" Call again with a larger max_tokens.\n")
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, result, nil
+	}
+}
+
+func encodeJSON(sb *strings.Builder, v any) error {
+	enc := json.NewEncoder(sb)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// fitProps keeps props in table order until they exceed maxTokens. It
+// returns the kept props and the names of the dropped ones.
+func fitProps(props []docs.Prop, maxTokens int) ([]docs.Prop, []string) {
+	total := 0
+	for i, prop := range props {
+		var sb strings.Builder
+		if err := encodeJSON(&sb, prop); err == nil {
+			total += docs.EstimateTokens(sb.String())
+		}
+		if total <= maxTokens {
+			continue
+		}
+
+		omitted := make([]string, 0, len(props)-i)
+		for _, p := range props[i:] {
+			omitted = append(omitted, p.Name)
+		}
+		return props[:i], omitted
+	}
+
+	return props, nil
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,37 +11,87 @@ import (
 )
 
 type SearchParams struct {
-	Query string `json:"query" jsonschema:"Search query to find in component names, descriptions, and documentation content"`
+	Query   string `json:"query" jsonschema:"Search query to find in component names, descriptions, and documentation content. Words are ANDed; supports \"quoted phrases\", -exclude and OR (e.g. 'date range -time', '\"date picker\" OR calendar')"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"Maximum number of results to return (default 10, max 50)"`
+	Offset  int    `json:"offset,omitempty" jsonschema:"Number of results to skip, for fetching the next page (see next_offset)"`
+	Version string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
 }
 
-func NewSearchHandler(store *docs.Store) func(context.Context, *mcp.CallToolRequest, *SearchParams) (*mcp.CallToolResult, any, error) {
-	return func(_ context.Context, _ *mcp.CallToolRequest, params *SearchParams) (*mcp.CallToolResult, any, error) {
+// maxSnippets is the number of matching lines shown per search result.
+const maxSnippets = 3
+
+type SearchResult struct {
+	Query   string      `json:"query"`
+	Results []SearchHit `json:"results"`
+	Page
+}
+
+type SearchHit struct {
+	docs.DocEntrySummary
+	Snippets []docs.Snippet `json:"snippets,omitempty"`
+}
+
+// EmptyResultFunc is called with the query or name a tool found nothing for.
+type EmptyResultFunc func(query string)
+
+func (fn EmptyResultFunc) report(query string) {
+	if fn != nil {
+		fn(query)
+	}
+}
+
+func NewSearchHandler(versions *docs.Versions, onEmpty EmptyResultFunc) func(context.Context, *mcp.CallToolRequest, *SearchParams) (*mcp.CallToolResult, *SearchResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *SearchParams) (*mcp.CallToolResult, *SearchResult, error) {
 		if params.Query == "" {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{&mcp.TextContent{Text: "query parameter is required"}},
-				IsError: true,
-			}, nil, nil
+			return nil, nil, errors.New("query parameter is required")
 		}
 
-		results := store.Search(params.Query)
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results, page := paginate(store.Search(params.Query), params.Offset, params.Limit, defaultSearchLimit, maxSearchLimit)
+
+		result := &SearchResult{Query: params.Query, Results: []SearchHit{}, Page: page}
+		for i := range results {
+			result.Results = append(result.Results, SearchHit{
+				DocEntrySummary: results[i].Summary(),
+				Snippets:        docs.Snippets(&results[i], params.Query, maxSnippets),
+			})
+		}
 
-		if len(results) == 0 {
+		if page.Total == 0 {
+			onEmpty.report(params.Query)
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No results found for: %s", params.Query)}},
-			}, nil, nil
+			}, result, nil
 		}
 
 		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("Found %d result(s) for \"%s\":\n\n", len(results), params.Query))
+		found := fmt.Sprintf("Found %d result(s) for \"%s\"", page.Total, params.Query)
+		sb.WriteString(page.header(found) + ":\n\n")
 
-		for _, entry := range results {
-			sb.WriteString(fmt.Sprintf("## %s [%s]\n", entry.Name, entry.Category))
-			sb.WriteString(entry.Description)
-			sb.WriteString("\n\n---\n\n")
+		for _, hit := range result.Results {
+			sb.WriteString(fmt.Sprintf("## %s [%s]\n", hit.Name, hit.Category))
+			sb.WriteString(hit.Description)
+			sb.WriteString("\n")
+			if len(hit.Snippets) > 0 {
+				sb.WriteString("\n")
+				for _, snippet := range hit.Snippets {
+					if snippet.Heading != "" {
+						sb.WriteString(fmt.Sprintf("- `%s`: %s\n", snippet.Heading, snippet.Text))
+					} else {
+						sb.WriteString(fmt.Sprintf("- %s\n", snippet.Text))
+					}
+				}
+			}
+			sb.WriteString("\n---\n\n")
 		}
+		sb.WriteString(page.footer())
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
-		}, nil, nil
+		}, result, nil
 	}
 }
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+type GetSectionParams struct {
+	Name      string `json:"name" jsonschema:"Component name (e.g. Button, Modal, DatePicker)"`
+	Section   string `json:"section,omitempty" jsonschema:"Section path separated by '/' (e.g. 'Props', 'Examples/Controlled'). Omit to list the available sections"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional approximate token budget for the response. When the docs are longer, lower-priority sections are left out (long examples first, props tables last) and a note lists what was omitted"`
+	Version   string `json:"version,omitempty" jsonschema:"Optional docs version (git tag or branch, see list_versions). Defaults to the main branch"`
+}
+
+// SectionResult carries the requested section, or only the outline of
+// available section paths when no section was given.
+type SectionResult struct {
+	Component string   `json:"component"`
+	Section   string   `json:"section,omitempty"`
+	Found     bool     `json:"found"`
+	Content   string   `json:"content,omitempty"`
+	Sections  []string `json:"sections"`
+	Omitted   []string `json:"omitted,omitempty"`
+}
+
+func NewGetSectionHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *GetSectionParams) (*mcp.CallToolResult, *SectionResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, params *GetSectionParams) (*mcp.CallToolResult, *SectionResult, error) {
+		if params.Name == "" {
+			return nil, nil, errors.New("name parameter is required")
+		}
+
+		store, err := resolveStore(versions, params.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry, note, err := resolveComponent(store, params.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &SectionResult{
+			Component: entry.Name,
+			Section:   params.Section,
+			Sections:  nonNil(entry.SectionPaths()),
+		}
+
+		if params.Section == "" {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: note + sectionOutline(entry)}},
+			}, result, nil
+		}
+
+		section := entry.FindSection(params.Section)
+		if section == nil {
+			return nil, nil, fmt.Errorf("%sSection not found in %s: %s\n\n%s", note, entry.Name, params.Section, sectionOutline(entry))
+		}
+
+		path := entry.SectionPath(section)
+		parent := ""
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			parent = path[:i]
+		}
+		content, omitted := fitContent(section.Content, params.MaxTokens, parent)
+
+		result.Section = path
+		result.Found = true
+		result.Content = content
+		result.Omitted = omitted
+
+		text := note + content + trimNote(params.MaxTokens, entry.Name, omitted)
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: text}},
+		}, result, nil
+	}
+}
+
+func sectionOutline(entry *docs.DocEntry) string {
+	paths := entry.SectionPaths()
+	if len(paths) == 0 {
+		return fmt.Sprintf("%s has no sections.", entry.Name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Sections of %s:\n\n", entry.Name))
+	for _, path := range paths {
+		sb.WriteString(fmt.Sprintf("- `%s`\n", path))
+	}
+
+	return sb.String()
+}
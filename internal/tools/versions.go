@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/vacano-house/vacano-ui-mcp/internal/docs"
+)
+
+type ListVersionsParams struct{}
+
+type VersionInfo struct {
+	Name    string `json:"name"`
+	Default bool   `json:"default"`
+	Entries int    `json:"entries"`
+	Icons   int    `json:"icons"`
+}
+
+type VersionsResult struct {
+	Versions []VersionInfo `json:"versions"`
+}
+
+func NewListVersionsHandler(versions *docs.Versions) func(context.Context, *mcp.CallToolRequest, *ListVersionsParams) (*mcp.CallToolResult, *VersionsResult, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, _ *ListVersionsParams) (*mcp.CallToolResult, *VersionsResult, error) {
+		result := &VersionsResult{Versions: []VersionInfo{}}
+
+		var sb strings.Builder
+		sb.WriteString("Available documentation versions:\n\n")
+
+		for _, name := range versions.Names() {
+			store, _ := versions.Get(name)
+			entries, icons := store.Counts()
+
+			isDefault := name == versions.DefaultName()
+			result.Versions = append(result.Versions, VersionInfo{Name: name, Default: isDefault, Entries: entries, Icons: icons})
+
+			marker := ""
+			if isDefault {
+				marker = " (default)"
+			}
+			sb.WriteString(fmt.Sprintf("- `%s`%s — %d entries, %d icons\n", name, marker, entries, icons))
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, result, nil
+	}
+}
+
+// resolveStore returns the store for the requested version, or the tool
+// error to return when the version is unknown.
+func resolveStore(versions *docs.Versions, version string) (*docs.Store, error) {
+	store, ok := versions.Get(version)
+	if ok {
+		return store, nil
+	}
+
+	return nil, fmt.Errorf("Unknown version: %s. Available versions: %s", version, strings.Join(versions.Names(), ", "))
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const maxPayloadSize = 5 << 20
+
+type pushPayload struct {
+	Ref string `json:"ref"`
+}
+
+// NewHandler accepts GitHub, GitLab and Gitea push webhooks. Requests are
+// authenticated with the shared secret: an HMAC-SHA256 signature for GitHub
+// (X-Hub-Signature-256) and Gitea (X-Gitea-Signature), or the secret token
+// for GitLab (X-Gitlab-Token). Pushes to a branch in targets call its
+// trigger; other refs are acknowledged and ignored.
+func NewHandler(secret string, targets map[string]func()) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		provider, ok := verify(r.Header, body, secret)
+		if !ok {
+			log.Printf("Webhook rejected: invalid signature from %s", r.RemoteAddr)
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		event := eventName(r.Header)
+		if event == "ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if event != "push" && event != "Push Hook" {
+			http.Error(w, "ignored event: "+event, http.StatusAccepted)
+			return
+		}
+
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		branch, isBranch := strings.CutPrefix(payload.Ref, "refs/heads/")
+		trigger, tracked := targets[branch]
+		if !isBranch || !tracked {
+			http.Error(w, "ignored ref: "+payload.Ref, http.StatusAccepted)
+			return
+		}
+
+		log.Printf("Webhook from %s: push to %s, refreshing", provider, branch)
+		trigger()
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func verify(header http.Header, body []byte, secret string) (string, bool) {
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		hexSig, found := strings.CutPrefix(signature, "sha256=")
+		return "github", found && validHMAC(body, secret, hexSig)
+	}
+
+	if signature := header.Get("X-Gitea-Signature"); signature != "" {
+		return "gitea", validHMAC(body, secret, signature)
+	}
+
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return "gitlab", subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	return "", false
+}
+
+func validHMAC(body []byte, secret, hexSig string) bool {
+	signature, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+func eventName(header http.Header) string {
+	for _, key := range []string{"X-GitHub-Event", "X-Gitea-Event", "X-Gitlab-Event"} {
+		if event := header.Get(key); event != "" {
+			return event
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSecret = "s3cret"
+
+func hmacHex(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func pushBody(ref string) string {
+	return `{"ref":"` + ref + `"}`
+}
+
+func TestHandler(t *testing.T) {
+	body := pushBody("refs/heads/master")
+	tampered := pushBody("refs/heads/release")
+
+	tests := []struct {
+		name        string
+		header      map[string]string
+		body        string
+		wantStatus  int
+		wantTrigger string
+	}{
+		{
+			name:        "github valid",
+			header:      map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hmacHex(testSecret, body)},
+			body:        body,
+			wantStatus:  http.StatusAccepted,
+			wantTrigger: "master",
+		},
+		{
+			name:       "github tampered body",
+			header:     map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hmacHex(testSecret, body)},
+			body:       tampered,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "github wrong secret",
+			header:     map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hmacHex("other", body)},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "github signature without sha256 prefix",
+			header:     map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": hmacHex(testSecret, body)},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "github missing signature",
+			header:     map[string]string{"X-GitHub-Event": "push"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "gitea valid",
+			header:      map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": hmacHex(testSecret, body)},
+			body:        body,
+			wantStatus:  http.StatusAccepted,
+			wantTrigger: "master",
+		},
+		{
+			name:       "gitea tampered body",
+			header:     map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": hmacHex(testSecret, body)},
+			body:       tampered,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "gitea malformed signature",
+			header:     map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": "not-hex"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "gitea missing signature",
+			header:     map[string]string{"X-Gitea-Event": "push"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "gitlab valid",
+			header:      map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": testSecret},
+			body:        body,
+			wantStatus:  http.StatusAccepted,
+			wantTrigger: "master",
+		},
+		{
+			name:       "gitlab wrong token",
+			header:     map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "other"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "gitlab missing token",
+			header:     map[string]string{"X-Gitlab-Event": "Push Hook"},
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "push to an extra version branch",
+			header:      map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hmacHex(testSecret, pushBody("refs/heads/v1"))},
+			body:        pushBody("refs/heads/v1"),
+			wantStatus:  http.StatusAccepted,
+			wantTrigger: "v1",
+		},
+		{
+			name:       "push to another branch",
+			header:     map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + hmacHex(testSecret, tampered)},
+			body:       tampered,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "tag named like a tracked branch",
+			header:     map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": testSecret},
+			body:       pushBody("refs/tags/master"),
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "ping",
+			header:     map[string]string{"X-GitHub-Event": "ping", "X-Hub-Signature-256": "sha256=" + hmacHex(testSecret, "{}")},
+			body:       "{}",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other event",
+			header:     map[string]string{"X-Gitea-Event": "issues", "X-Gitea-Signature": hmacHex(testSecret, body)},
+			body:       body,
+			wantStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var triggered []string
+			targets := map[string]func(){
+				"master": func() { triggered = append(triggered, "master") },
+				"v1":     func() { triggered = append(triggered, "v1") },
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tt.body))
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			NewHandler(testSecret, targets).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.wantStatus, strings.TrimSpace(rec.Body.String()))
+			}
+
+			switch {
+			case tt.wantTrigger == "" && len(triggered) > 0:
+				t.Errorf("triggered %v, want no refresh", triggered)
+			case tt.wantTrigger != "" && (len(triggered) != 1 || triggered[0] != tt.wantTrigger):
+				t.Errorf("triggered %v, want [%s]", triggered, tt.wantTrigger)
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsGet(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(testSecret, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}